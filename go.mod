@@ -12,9 +12,13 @@ require (
 	github.com/grpc-ecosystem/go-grpc-middleware v1.0.0
 	github.com/grpc-ecosystem/grpc-gateway v1.8.5
 	github.com/jmoiron/sqlx v1.2.0
+	github.com/lib/pq v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/oschwald/maxminddb-golang v1.8.0
 	github.com/robfig/cron v0.0.0-20180505203441-b41be1df6967
 	go.uber.org/zap v1.10.0
 	golang.org/x/net v0.0.0-20200202094626-16171245cfb2
+	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
 	google.golang.org/genproto v0.0.0-20200212174721-66ed5ce911ce
 	google.golang.org/grpc v1.27.1
 )
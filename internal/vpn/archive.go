@@ -0,0 +1,179 @@
+package vpn
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// archiveFeedExt is extension of the gzip compressed raw feeds
+	archiveFeedExt = ".gz"
+	// archiveMetaExt is extension of the JSON metadata written next to every archived feed
+	archiveMetaExt = ".json"
+	// archiveTimeFormat is format of the fetch time in archived file names
+	archiveTimeFormat = "20060102T150405.000Z"
+)
+
+// ArchiveMeta is the metadata of an archived feed
+type ArchiveMeta struct {
+	Source    string      `json:"source"`
+	URL       string      `json:"url"`
+	FetchedAt time.Time   `json:"fetchedAt"`
+	Header    http.Header `json:"header,omitempty"`
+	// Size is size of the uncompressed feed in bytes
+	Size int `json:"size"`
+}
+
+// Archive keeps the raw feeds downloaded by the crawler in a directory. Every feed is stored as
+// <source>-<time>.gz with its metadata in <source>-<time>.json, the oldest feeds are pruned once
+// they are older than maxAge or the archive is bigger than maxSize bytes.
+type Archive struct {
+	dir     string
+	maxAge  time.Duration
+	maxSize int64
+}
+
+// Save compresses feed into the archive and prunes the archive, it returns path of the archived feed
+func (a *Archive) Save(source string, feed *Feed, fetchedAt time.Time) (string, error) {
+	if err := os.MkdirAll(a.dir, 0755); err != nil {
+		return "", err
+	}
+	base := filepath.Join(a.dir, source+"-"+fetchedAt.UTC().Format(archiveTimeFormat))
+
+	f, err := os.Create(base + archiveFeedExt)
+	if err != nil {
+		return "", err
+	}
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write(feed.Content)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(base + archiveFeedExt)
+		return "", err
+	}
+
+	meta, err := json.MarshalIndent(ArchiveMeta{
+		Source:    source,
+		URL:       feed.URL,
+		FetchedAt: fetchedAt.UTC(),
+		Header:    feed.Header,
+		Size:      len(feed.Content),
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(base+archiveMetaExt, meta, 0644); err != nil {
+		return "", err
+	}
+	return base + archiveFeedExt, a.Prune(time.Now())
+}
+
+// Prune removes the feeds older than maxAge, then the oldest feeds until the archive fits in maxSize
+func (a *Archive) Prune(now time.Time) error {
+	entries, err := ioutil.ReadDir(a.dir)
+	if err != nil {
+		return err
+	}
+	type archived struct {
+		base    string
+		modTime time.Time
+		size    int64
+	}
+	files := make(map[string]*archived)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != archiveFeedExt && ext != archiveMetaExt) {
+			continue
+		}
+		base := strings.TrimSuffix(e.Name(), ext)
+		f, ok := files[base]
+		if !ok {
+			f = &archived{base: base, modTime: e.ModTime()}
+			files[base] = f
+		}
+		f.size += e.Size()
+		if e.ModTime().Before(f.modTime) {
+			f.modTime = e.ModTime()
+		}
+	}
+	var sorted []*archived
+	var total int64
+	for _, f := range files {
+		sorted = append(sorted, f)
+		total += f.size
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].modTime.Before(sorted[j].modTime)
+	})
+	// the newest feed is kept whatever its size so the last crawl can always be replayed
+	for i, f := range sorted {
+		if i == len(sorted)-1 {
+			break
+		}
+		expired := a.maxAge > 0 && now.Sub(f.modTime) > a.maxAge
+		oversized := a.maxSize > 0 && total > a.maxSize
+		if !expired && !oversized {
+			break
+		}
+		for _, ext := range []string{archiveFeedExt, archiveMetaExt} {
+			if err := os.Remove(filepath.Join(a.dir, f.base+ext)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		total -= f.size
+	}
+	return nil
+}
+
+// OpenArchivedFeed reads an archived feed and its metadata, the metadata is empty when its file is missing
+func OpenArchivedFeed(path string) (*Feed, *ArchiveMeta, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gz.Close()
+	content, err := ioutil.ReadAll(gz)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := &ArchiveMeta{}
+	raw, err := ioutil.ReadFile(strings.TrimSuffix(path, archiveFeedExt) + archiveMetaExt)
+	if err == nil {
+		err = json.Unmarshal(raw, meta)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	return &Feed{
+		Content: content,
+		URL:     meta.URL,
+		Header:  meta.Header,
+	}, meta, nil
+}
+
+// NewArchive creates an archive in dir, a zero maxAge or maxSize disables that limit
+func NewArchive(dir string, maxAge time.Duration, maxSize int64) *Archive {
+	return &Archive{
+		dir:     dir,
+		maxAge:  maxAge,
+		maxSize: maxSize,
+	}
+}
@@ -0,0 +1,116 @@
+package vpn
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestArchive_Save(t *testing.T) {
+	dir := t.TempDir()
+	fetchedAt := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	feed := &Feed{
+		Content: []byte("*vpn_servers\n*\n"),
+		URL:     "http://www.vpngate.net/api/iphone/",
+		Header:  http.Header{"Etag": []string{`"v1"`}},
+	}
+	path, err := NewArchive(dir, 0, 0).Save("vpngate", feed, fetchedAt)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if want := filepath.Join(dir, "vpngate-20200301T000000.000Z.gz"); path != want {
+		t.Errorf("Save() = %v, want %v", path, want)
+	}
+	got, meta, err := OpenArchivedFeed(path)
+	if err != nil {
+		t.Fatalf("OpenArchivedFeed() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, feed) {
+		t.Errorf("OpenArchivedFeed() = %+v, want %+v", got, feed)
+	}
+	if meta.Source != "vpngate" || !meta.FetchedAt.Equal(fetchedAt) || meta.Size != len(feed.Content) {
+		t.Errorf("OpenArchivedFeed() meta = %+v", meta)
+	}
+}
+
+func TestArchive_Prune(t *testing.T) {
+	now := time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		maxAge  time.Duration
+		maxSize int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			"No limit keeps everything",
+			args{
+				0,
+				0,
+			},
+			[]string{"a", "b", "c"},
+		},
+		{
+			"Feeds older than max age are removed",
+			args{
+				36 * time.Hour,
+				0,
+			},
+			[]string{"b", "c"},
+		},
+		{
+			"Oldest feeds are removed until the archive fits",
+			args{
+				0,
+				25,
+			},
+			[]string{"b", "c"},
+		},
+		{
+			"Newest feed is kept whatever its size",
+			args{
+				time.Minute,
+				1,
+			},
+			[]string{"c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// a is 3 days old, b 1 day and c is new, every feed and its metadata weigh 10 bytes
+			ages := map[string]time.Duration{"a": 72 * time.Hour, "b": 24 * time.Hour, "c": 0}
+			for base, age := range ages {
+				modTime := now.Add(-age)
+				for _, ext := range []string{archiveFeedExt, archiveMetaExt} {
+					path := filepath.Join(dir, base+ext)
+					if err := ioutil.WriteFile(path, []byte("12345"), 0644); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.Chtimes(path, modTime, modTime); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if err := NewArchive(dir, tt.args.maxAge, tt.args.maxSize).Prune(now); err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			matches, _ := filepath.Glob(filepath.Join(dir, "*"+archiveFeedExt))
+			var got []string
+			for _, m := range matches {
+				got = append(got, filepath.Base(m[:len(m)-len(archiveFeedExt)]))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Prune() kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package vpn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// maxCacheEntries is the largest number of lists a cacheRepository holds, lists of queries beyond
+	// it are not cached until the expired ones are dropped
+	maxCacheEntries = 1024
+)
+
+// CacheStats are the lookup counters of a cacheRepository
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// cacheRepository keeps the country and VPN server lists read by clients in memory for a TTL, every
+// other call goes to repo. The lists are dropped when a transaction, that is a crawl, or probe results
+// are saved through it, replicas that did not run the crawl see the new lists once the TTL elapsed.
+// Concurrent misses of the same list share a single query.
+type cacheRepository struct {
+	Repository
+	ttl time.Duration
+	// loadTimeout bounds the queries shared by concurrent misses, zero disables the bound
+	loadTimeout time.Duration
+	group       singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generation is bumped on every invalidation so lists queried before it are not cached
+	generation uint64
+
+	hits   int64
+	misses int64
+}
+
+// cacheEntry is a cached list
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// vpnServerPage is a cached result of FindVPNServers
+type vpnServerPage struct {
+	servers []*VPNServer
+	total   int64
+}
+
+// Stats returns the lookup counters since the repository was created
+func (c *cacheRepository) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+	}
+}
+
+// Invalidate drops every cached list
+func (c *cacheRepository) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+	c.generation++
+}
+
+// get returns the cached value of key, or loads and caches it. Concurrent loads of a key are merged,
+// the shared load runs on its own context bounded by loadTimeout so a caller that goes away does not
+// fail the others, every caller stops waiting when its ctx is done.
+func (c *cacheRepository) get(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		atomic.AddInt64(&c.hits, 1)
+		return entry.value, nil
+	}
+	atomic.AddInt64(&c.misses, 1)
+
+	// the generation is part of the flight so callers arriving after an invalidation do not join a stale load
+	flight := c.group.DoChan(key+"@"+strconv.FormatUint(generation, 10), func() (interface{}, error) {
+		// a flight that ended since the lookup may have cached the list already
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && time.Now().Before(entry.expiresAt) {
+			return entry.value, nil
+		}
+		loadCtx, cancel := withTimeout(context.Background(), c.loadTimeout)
+		defer cancel()
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.store(key, value, generation)
+		return value, nil
+	})
+	select {
+	case res := <-flight:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// store caches value of key unless the cache was invalidated since generation
+func (c *cacheRepository) store(key string, value interface{}, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	now := time.Now()
+	if len(c.entries) >= maxCacheEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = cacheEntry{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// copyServers returns a copy of a cached list so callers may filter it in place
+func copyServers(servers []*VPNServer) []*VPNServer {
+	if servers == nil {
+		return nil
+	}
+	return append([]*VPNServer(nil), servers...)
+}
+
+func (c *cacheRepository) FindAllCountryHaveVPNServer(ctx context.Context) ([]*Country, error) {
+	value, err := c.get(ctx, "countries", func(ctx context.Context) (interface{}, error) {
+		return c.Repository.FindAllCountryHaveVPNServer(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	countries := value.([]*Country)
+	if countries == nil {
+		return nil, nil
+	}
+	return append([]*Country(nil), countries...), nil
+}
+
+func (c *cacheRepository) FindVPNServerByCountryCode(ctx context.Context, code string) ([]*VPNServer, error) {
+	value, err := c.get(ctx, "servers/"+code, func(ctx context.Context) (interface{}, error) {
+		return c.Repository.FindVPNServerByCountryCode(ctx, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyServers(value.([]*VPNServer)), nil
+}
+
+func (c *cacheRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	value, err := c.get(ctx, "servers", func(ctx context.Context) (interface{}, error) {
+		return c.Repository.FindAllVPNServer(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyServers(value.([]*VPNServer)), nil
+}
+
+func (c *cacheRepository) FindVPNServers(ctx context.Context, query VPNServerQuery) ([]*VPNServer, int64, error) {
+	key := fmt.Sprintf("query/%s/%d/%d/%d/%s/%s/%d/%s/%t/%d",
+		query.CountryCode, query.MinSpeed, query.MaxPing, query.MaxSessions, query.Protocol, query.Operator,
+		query.MaxProbeFailures, query.SortBy, query.Desc, query.Limit)
+	if query.After != nil {
+		key += fmt.Sprintf("/%d/%d", query.After.Value, query.After.ID)
+	}
+	if len(query.ExcludeIDs) > 0 {
+		key += fmt.Sprintf("/%v", query.ExcludeIDs)
+	}
+	value, err := c.get(ctx, key, func(ctx context.Context) (interface{}, error) {
+		servers, total, err := c.Repository.FindVPNServers(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return &vpnServerPage{servers: servers, total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	page := value.(*vpnServerPage)
+	return copyServers(page.servers), page.total, nil
+}
+
+func (c *cacheRepository) FindRuleTargets(ctx context.Context) ([]*VPNServer, error) {
+	value, err := c.get(ctx, "rule-targets", func(ctx context.Context) (interface{}, error) {
+		return c.Repository.FindRuleTargets(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyServers(value.([]*VPNServer)), nil
+}
+
+func (c *cacheRepository) UpdateReachability(ctx context.Context, results []Reachability) error {
+	if err := c.Repository.UpdateReachability(ctx, results); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *cacheRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	if err := c.Repository.Transaction(ctx, fn); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+// NewCacheRepository caches the client read lists of repo for ttl, the queries loading them are bounded
+// by loadTimeout
+func NewCacheRepository(repo Repository, ttl, loadTimeout time.Duration) Repository {
+	return &cacheRepository{
+		Repository:  repo,
+		ttl:         ttl,
+		loadTimeout: loadTimeout,
+		entries:     make(map[string]cacheEntry),
+	}
+}
@@ -0,0 +1,139 @@
+package vpn
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingRepository counts the server list queries, a query waits for release when it is set
+type countingRepository struct {
+	Repository
+	queries int64
+	release chan struct{}
+}
+
+func (r *countingRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	atomic.AddInt64(&r.queries, 1)
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []*VPNServer{{ID: 1, ProbeFailures: 5}, {ID: 2}}, nil
+}
+
+func (r *countingRepository) Transaction(context.Context, func(Tx) error) error {
+	return nil
+}
+
+func Test_cacheRepository(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		invalidate  bool
+		wantQueries int64
+		wantStats   CacheStats
+	}{
+		{"Second read should be a hit", time.Hour, false, 1, CacheStats{Hits: 1, Misses: 1}},
+		{"Expired list should be queried again", time.Nanosecond, false, 2, CacheStats{Hits: 0, Misses: 2}},
+		{"Crawl should invalidate the lists", time.Hour, true, 2, CacheStats{Hits: 0, Misses: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counting := &countingRepository{}
+			repo := NewCacheRepository(counting, tt.ttl, 0)
+			first, err := repo.FindAllVPNServer(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// callers filter the lists in place
+			filterReachable(first, 3)
+			if tt.invalidate {
+				if err := repo.Transaction(context.Background(), func(Tx) error { return nil }); err != nil {
+					t.Fatal(err)
+				}
+			}
+			time.Sleep(time.Millisecond)
+			second, err := repo.FindAllVPNServer(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(second) != 2 || second[0].ID != 1 {
+				t.Errorf("FindAllVPNServer() = %+v, want the servers 1 and 2", second)
+			}
+			if counting.queries != tt.wantQueries {
+				t.Errorf("queries = %d, want %d", counting.queries, tt.wantQueries)
+			}
+			if got := repo.(*cacheRepository).Stats(); got != tt.wantStats {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func Test_cacheRepository_singleflight(t *testing.T) {
+	counting := &countingRepository{release: make(chan struct{})}
+	repo := NewCacheRepository(counting, time.Hour, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.FindAllVPNServer(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	// every reader has missed before the query is released
+	for repo.(*cacheRepository).Stats().Misses < 10 {
+		time.Sleep(time.Millisecond)
+	}
+	close(counting.release)
+	wg.Wait()
+	if counting.queries != 1 {
+		t.Errorf("queries = %d, want 1", counting.queries)
+	}
+}
+
+func Test_cacheRepository_canceledCaller(t *testing.T) {
+	counting := &countingRepository{release: make(chan struct{})}
+	repo := NewCacheRepository(counting, time.Hour, 0)
+	leadCtx, cancel := context.WithCancel(context.Background())
+	leadErr := make(chan error, 1)
+	go func() {
+		_, err := repo.FindAllVPNServer(leadCtx)
+		leadErr <- err
+	}()
+	for atomic.LoadInt64(&counting.queries) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// a second reader joins the query started by the first one, then the first one goes away
+	servers := make(chan []*VPNServer, 1)
+	go func() {
+		res, err := repo.FindAllVPNServer(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		servers <- res
+	}()
+	for repo.(*cacheRepository).Stats().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-leadErr; err != context.Canceled {
+		t.Errorf("FindAllVPNServer() of the canceled reader error = %v, want %v", err, context.Canceled)
+	}
+	close(counting.release)
+	if res := <-servers; len(res) != 2 {
+		t.Errorf("FindAllVPNServer() = %+v, want the servers 1 and 2", res)
+	}
+	if counting.queries != 1 {
+		t.Errorf("queries = %d, want 1", counting.queries)
+	}
+}
@@ -0,0 +1,298 @@
+package vpn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"squirrel-srv/pkg/fetch"
+	"squirrel-srv/pkg/geoip"
+	"squirrel-srv/pkg/logger"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCrawlInProgress is returned by CrawlAll while another crawl of the crawler runs
+var ErrCrawlInProgress = errors.New("a crawl is already in progress")
+
+// Crawler pulls VPN servers from the enabled sources and persists them
+type Crawler struct {
+	repo    Repository
+	sources []Source
+	// geo locates servers, servers are not located when it is nil
+	geo *geoip.DB
+	// archive keeps the raw feeds, feeds are not archived when it is nil
+	archive *Archive
+	// batchSize is the number of servers upserted by a single statement
+	batchSize int
+	// guard refuses the crawls that would replace the servers of a source with anomalous data
+	guard CrawlGuard
+	// crawling is 1 while CrawlAll runs, the scheduled and the requested crawls skip instead of overlapping
+	crawling int32
+}
+
+// Sources returns the sources enabled for the crawler
+func (c *Crawler) Sources() []Source {
+	return c.sources
+}
+
+// Crawl fetches and parses a source, then atomically upserts its servers and soft deletes the vanished ones.
+// Every crawl is recorded as a crawl run. fetch.ErrNotModified is returned without touching the servers
+// when the feed did not change since the last crawl, an *AnomalyError when the crawl guard refused the crawl.
+func (c *Crawler) Crawl(ctx context.Context, src Source) ([]*VPNServer, error) {
+	run := CrawlRun{
+		Source:    src.Name(),
+		StartedAt: time.Now(),
+		Status:    crawlRunRunning,
+	}
+	if id, err := c.repo.CreateCrawlRun(ctx, run); err != nil {
+		logger.Log.Warn("create " + src.Name() + " crawl run error: " + err.Error())
+	} else {
+		run.ID = int32(id)
+	}
+
+	servers, err := c.crawl(ctx, src, &run)
+	if err == nil {
+		logger.Log.Info("crawled " + src.Name() + " success " + strconv.Itoa(len(servers)) + " items" +
+			", inserted " + strconv.Itoa(int(run.Inserted)) +
+			", updated " + strconv.Itoa(int(run.Updated)) +
+			", removed " + strconv.Itoa(int(run.Removed)) +
+			", db time " + strconv.Itoa(int(run.DBTimeMs)) + "ms")
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	var anomaly *AnomalyError
+	switch {
+	case err == nil:
+		run.Status = crawlRunSucceeded
+	case err == fetch.ErrNotModified:
+		run.Status = crawlRunSkipped
+	case errors.As(err, &anomaly):
+		run.Status = crawlRunRejected
+		run.Error = err.Error()
+	default:
+		run.Status = crawlRunFailed
+		run.Error = err.Error()
+	}
+	if run.ID != 0 {
+		if err := c.repo.UpdateCrawlRun(ctx, run); err != nil {
+			logger.Log.Warn("update " + src.Name() + " crawl run error: " + err.Error())
+		}
+	}
+	return servers, err
+}
+
+// crawl runs the crawl of a source and fills run with its counters
+func (c *Crawler) crawl(ctx context.Context, src Source, run *CrawlRun) ([]*VPNServer, error) {
+	feed, err := src.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.archive != nil {
+		if _, err := c.archive.Save(src.Name(), feed, run.StartedAt); err != nil {
+			logger.Log.Warn("archive " + src.Name() + " feed error: " + err.Error())
+		}
+	}
+	parsed, err := src.Parse(feed.Content)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Truncated {
+		logger.Log.Warn(src.Name() + " feed has no end marker, it may be truncated")
+	}
+	// servers denied by a rule are stored all the same and left out of the lists when read,
+	// so that they come back as soon as the rule is deleted or expires
+	servers, invalid := applyOpenVPNConfigs(parsed.Servers)
+	rejected := append(parsed.Rejected, invalid...)
+	for _, r := range rejected {
+		logger.Log.Warn("reject "+src.Name()+" row: "+r.Reason,
+			zap.Int("line", r.Line), zap.String("host", r.HostName), zap.String("ip", r.IP))
+	}
+	run.RowsFetched = int32(parsed.Rows)
+	run.RowsParsed = int32(len(servers))
+	run.RowsRejected = int32(len(rejected))
+	for _, srv := range servers {
+		srv.Source = src.Name()
+	}
+	counts := CrawlCounts{
+		Rows:     parsed.Rows,
+		Rejected: len(rejected),
+	}
+	if counts.Previous, err = c.repo.CountVPNServers(ctx, src.Name()); err != nil {
+		return nil, err
+	}
+	if c.guard.MaxDropRejections > 0 {
+		// the run of this crawl is among the runs, it is skipped as it is running
+		runs, err := c.repo.FindCrawlRuns(ctx, src.Name(), uint64(c.guard.MaxDropRejections)+1)
+		if err != nil {
+			return nil, err
+		}
+		counts.DropRejections = dropRejections(runs)
+	}
+	if err := c.guard.Check(servers, counts); err != nil {
+		logger.Log.Error("crawl "+src.Name()+" rejected, previous servers are kept: "+err.Error(),
+			zap.String("check", err.(*AnomalyError).Check))
+		return nil, err
+	}
+	c.locate(servers)
+	// upsert the crawl as a new snapshot and soft delete servers that vanished from it,
+	// readers keep seeing the previous state until the transaction is committed
+	var removed int64
+	dbStartedAt := time.Now()
+	err = c.repo.Transaction(ctx, func(tx Tx) error {
+		snapshotID, err := tx.CreateSnapshot(ctx, src.Name())
+		if err != nil {
+			return err
+		}
+		countries := make([]Country, 0, len(servers))
+		for _, srv := range servers {
+			countries = append(countries, srv.Country)
+		}
+		countryIDs, err := tx.ResolveCountries(ctx, countries)
+		if err != nil {
+			return err
+		}
+		for _, srv := range servers {
+			srv.CountryID = countryIDs[srv.Country.Code]
+			srv.SnapshotID = int32(snapshotID)
+		}
+		for _, batch := range batches(servers, c.batchSize) {
+			inserted, updated, err := tx.UpsertBatch(ctx, batch)
+			if err != nil {
+				return err
+			}
+			run.Inserted += int32(inserted)
+			run.Updated += int32(updated)
+		}
+		if removed, err = tx.DeleteMissing(ctx, src.Name(), snapshotID); err != nil {
+			return err
+		}
+		if _, err := tx.RecordMetrics(ctx, snapshotID); err != nil {
+			return err
+		}
+		return tx.ActivateSnapshot(ctx, src.Name(), snapshotID)
+	})
+	run.DBTimeMs = int32(time.Since(dbStartedAt).Milliseconds())
+	if err != nil {
+		run.Inserted, run.Updated = 0, 0
+		return nil, err
+	}
+	run.Removed = int32(removed)
+	if cs, ok := src.(CommitSource); ok {
+		cs.Commit()
+	}
+	if err := c.repo.PurgeSnapshots(ctx, src.Name()); err != nil {
+		logger.Log.Warn("purge " + src.Name() + " snapshots error: " + err.Error())
+	}
+	return servers, nil
+}
+
+// CrawlAll crawls every enabled source, a failing source does not stop the others.
+// ErrCrawlInProgress is returned without crawling when another CrawlAll is running.
+func (c *Crawler) CrawlAll(ctx context.Context) ([]*VPNServer, error) {
+	if !atomic.CompareAndSwapInt32(&c.crawling, 0, 1) {
+		return nil, ErrCrawlInProgress
+	}
+	defer atomic.StoreInt32(&c.crawling, 0)
+	var all []*VPNServer
+	var lastErr error
+	for _, src := range c.sources {
+		servers, err := c.Crawl(ctx, src)
+		if err == fetch.ErrNotModified {
+			logger.Log.Info("crawl " + src.Name() + " skipped, feed not modified")
+			continue
+		}
+		if err != nil {
+			// rejected crawls are already logged at error level by Crawl
+			var anomaly *AnomalyError
+			if !errors.As(err, &anomaly) {
+				logger.Log.Warn("crawl " + src.Name() + " error: " + err.Error())
+			}
+			lastErr = err
+			continue
+		}
+		all = append(all, servers...)
+	}
+	return all, lastErr
+}
+
+// batches splits servers in consecutive batches of at most size servers, a size below 1 is a single batch
+func batches(servers []*VPNServer, size int) [][]*VPNServer {
+	if size < 1 {
+		size = len(servers)
+	}
+	var res [][]*VPNServer
+	for len(servers) > 0 {
+		n := size
+		if n > len(servers) {
+			n = len(servers)
+		}
+		res = append(res, servers[:n])
+		servers = servers[n:]
+	}
+	return res
+}
+
+// applyOpenVPNConfigs fills the connection fields of servers from their OpenVPN config.
+// Servers with an undecodable config are rejected.
+func applyOpenVPNConfigs(servers []*VPNServer) ([]*VPNServer, []RejectedRow) {
+	accepted := servers[:0]
+	var rejected []RejectedRow
+	for _, srv := range servers {
+		cfg, err := ParseOpenVPNConfig(srv.OpenVPNConfig)
+		if err != nil {
+			rejected = append(rejected, RejectedRow{HostName: srv.HostName, IP: srv.IP, Reason: err.Error()})
+			continue
+		}
+		srv.Protocol = cfg.Protocol
+		srv.Port = cfg.Port
+		srv.RemoteHosts = strings.Join(cfg.RemoteHosts, ",")
+		srv.Cipher = cfg.Cipher
+		srv.AuthDigest = cfg.AuthDigest
+		srv.Compression = cfg.Compression
+		srv.EmbedsCA = cfg.EmbedsCA
+		srv.EmbedsCert = cfg.EmbedsCert
+		srv.EmbedsKey = cfg.EmbedsKey
+		accepted = append(accepted, srv)
+	}
+	return accepted, rejected
+}
+
+// locate fills the coordinates of servers from the GeoIP database
+func (c *Crawler) locate(servers []*VPNServer) {
+	if c.geo == nil {
+		return
+	}
+	for _, srv := range servers {
+		loc, err := c.geo.Lookup(net.ParseIP(srv.IP))
+		if err != nil {
+			logger.Log.Debug("locate server "+srv.HostName+": "+err.Error(), zap.String("ip", srv.IP))
+			continue
+		}
+		srv.Latitude = &loc.Latitude
+		srv.Longitude = &loc.Longitude
+	}
+}
+
+// NewCrawler creates a crawler for the given sources, geo may be nil to leave servers unlocated
+// and archive may be nil to not archive feeds
+func NewCrawler(repo Repository, sources []Source, geo *geoip.DB, archive *Archive, cfg Config) *Crawler {
+	return &Crawler{
+		repo:      repo,
+		sources:   sources,
+		geo:       geo,
+		archive:   archive,
+		batchSize: cfg.CrawlerBatchSize,
+		guard: CrawlGuard{
+			MinServers:        cfg.CrawlerMinServers,
+			MaxDropPercent:    cfg.CrawlerMaxDropPercent,
+			MaxDropRejections: cfg.CrawlerMaxDropRejections,
+			MinCountries:      cfg.CrawlerMinCountries,
+			MaxRejectedRatio:  cfg.CrawlerMaxRejectedRatio,
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package vpn
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"testing"
+)
+
+func Test_batches(t *testing.T) {
+	servers := []*VPNServer{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	type args struct {
+		servers []*VPNServer
+		size    int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int32
+	}{
+		{
+			"Last batch should hold the remainder",
+			args{
+				servers,
+				2,
+			},
+			[][]int32{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			"Size above the number of servers should be a single batch",
+			args{
+				servers,
+				10,
+			},
+			[][]int32{{1, 2, 3, 4, 5}},
+		},
+		{
+			"Zero size should be a single batch",
+			args{
+				servers,
+				0,
+			},
+			[][]int32{{1, 2, 3, 4, 5}},
+		},
+		{
+			"No servers should be no batch",
+			args{
+				nil,
+				2,
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := batches(tt.args.servers, tt.args.size)
+			if len(got) != len(tt.want) {
+				t.Fatalf("batches() got %d batches, want %d", len(got), len(tt.want))
+			}
+			for i, batch := range got {
+				if len(batch) != len(tt.want[i]) {
+					t.Fatalf("batches() batch %d has %d servers, want %d", i, len(batch), len(tt.want[i]))
+				}
+				for j, srv := range batch {
+					if srv.ID != tt.want[i][j] {
+						t.Errorf("batches() batch %d server %d = %d, want %d", i, j, srv.ID, tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+// testSource serves a feed of its servers
+type testSource struct {
+	servers []*VPNServer
+}
+
+func (s *testSource) Name() string {
+	return "test"
+}
+
+func (s *testSource) Fetch(context.Context) (*Feed, error) {
+	return &Feed{}, nil
+}
+
+func (s *testSource) Parse([]byte) (*ParseResult, error) {
+	// the crawler fills the servers it crawls, every parse returns new ones
+	var servers []*VPNServer
+	for _, srv := range s.servers {
+		server := *srv
+		servers = append(servers, &server)
+	}
+	return &ParseResult{Servers: servers, Rows: len(servers)}, nil
+}
+
+// testFeedServers returns n servers of a country with a usable OpenVPN config
+func testFeedServers(code string, n int) []*VPNServer {
+	config := base64.StdEncoding.EncodeToString([]byte("client\nremote 1.1.1.1 1194\n"))
+	var servers []*VPNServer
+	for i := 0; i < n; i++ {
+		servers = append(servers, &VPNServer{
+			HostName:      code + "-" + strconv.Itoa(i),
+			IP:            "10.0." + strconv.Itoa(int(code[0])) + "." + strconv.Itoa(i),
+			Country:       Country{Name: code, Code: code},
+			OpenVPNConfig: config,
+		})
+	}
+	return servers
+}
+
+func Test_Crawler_guard(t *testing.T) {
+	feed := append(testFeedServers("JP", 8), testFeedServers("KR", 2)...)
+	type crawl struct {
+		servers    []*VPNServer
+		rule       *ServerRule
+		wantCheck  string
+		wantStored int64
+	}
+	tests := []struct {
+		name   string
+		crawls []crawl
+	}{
+		{
+			"Servers denied by a new rule should be kept stored",
+			[]crawl{
+				{feed, nil, "", 10},
+				{feed, &ServerRule{Action: ruleActionDeny, CountryCode: "JP"}, "", 10},
+				{feed, nil, "", 10},
+			},
+		},
+		{
+			"Lasting drop should be accepted after the max drop rejections",
+			[]crawl{
+				{feed, nil, "", 10},
+				{feed[:4], nil, guardCheckMaxDrop, 10},
+				{feed[:4], nil, guardCheckMaxDrop, 10},
+				{feed[:4], nil, "", 4},
+				{feed[:4], nil, "", 4},
+			},
+		},
+		{
+			"Accepted crawl should end the max drop rejections",
+			[]crawl{
+				{feed, nil, "", 10},
+				{feed[:4], nil, guardCheckMaxDrop, 10},
+				{feed, nil, "", 10},
+				{feed[:4], nil, guardCheckMaxDrop, 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepository()
+			src := &testSource{}
+			crawler := NewCrawler(repo, []Source{src}, nil, nil, Config{
+				CrawlerMaxDropPercent:    50,
+				CrawlerMaxDropRejections: 2,
+			})
+			for i, c := range tt.crawls {
+				if c.rule != nil {
+					if _, err := repo.CreateServerRule(context.Background(), *c.rule); err != nil {
+						t.Fatal(err)
+					}
+				}
+				src.servers = c.servers
+				_, err := crawler.Crawl(context.Background(), src)
+				var check string
+				if anomaly, ok := err.(*AnomalyError); ok {
+					check = anomaly.Check
+				} else if err != nil {
+					t.Fatalf("crawl %d error = %v", i, err)
+				}
+				if check != c.wantCheck {
+					t.Errorf("crawl %d rejected by check '%s', want '%s'", i, check, c.wantCheck)
+				}
+				stored, err := repo.CountVPNServers(context.Background(), src.Name())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored != c.wantStored {
+					t.Errorf("crawl %d stored %d servers, want %d", i, stored, c.wantStored)
+				}
+			}
+		})
+	}
+}
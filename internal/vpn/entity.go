@@ -1,6 +1,9 @@
 package vpn
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 // Country entity
 type Country struct {
@@ -29,8 +32,81 @@ type VPNServer struct {
 	Operator       string     `db:"operator"`
 	Message        string     `db:"message"`
 	OpenVPNConfig  string     `db:"open_vpn_config"`
+	Protocol       string     `db:"protocol"`
+	Port           int32      `db:"port"`
+	RemoteHosts    string     `db:"remote_hosts"`
+	Cipher         string     `db:"cipher"`
+	AuthDigest     string     `db:"auth_digest"`
+	Compression    string     `db:"compression"`
+	EmbedsCA       bool       `db:"embeds_ca"`
+	EmbedsCert     bool       `db:"embeds_cert"`
+	EmbedsKey      bool       `db:"embeds_key"`
+	Latitude       *float64   `db:"latitude"`
+	Longitude      *float64   `db:"longitude"`
+	Reachable      bool       `db:"reachable"`
+	ProbeLatencyMs int32      `db:"probe_latency_ms"`
+	ProbeFailures  int32      `db:"probe_failures"`
+	ProbedAt       *time.Time `db:"probed_at"`
+	Source         string     `db:"source"`
+	SnapshotID     int32      `db:"snapshot_id"`
 	CreatedAt      time.Time  `db:"created_at"`
 	UpdatedAt      time.Time  `db:"updated_at"`
 	DeletedAt      *time.Time `db:"deleted_at"`
+	LastSeenAt     time.Time  `db:"last_seen_at"`
 	Country        `db:"country"`
 }
+
+// CrawlRun entity records a single crawl of a source
+type CrawlRun struct {
+	ID           int32      `db:"id"`
+	Source       string     `db:"source"`
+	Status       string     `db:"status"`
+	StartedAt    time.Time  `db:"started_at"`
+	FinishedAt   *time.Time `db:"finished_at"`
+	RowsFetched  int32      `db:"rows_fetched"`
+	RowsParsed   int32      `db:"rows_parsed"`
+	RowsRejected int32      `db:"rows_rejected"`
+	Inserted     int32      `db:"inserted"`
+	Updated      int32      `db:"updated"`
+	Removed      int32      `db:"removed"`
+	DBTimeMs     int32      `db:"db_time_ms"`
+	Error        string     `db:"error"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
+
+// ServerMetric entity is a point of the metric history of a VPN server recorded by a crawl
+type ServerMetric struct {
+	ID             int64     `db:"id"`
+	ServerID       int32     `db:"server_id"`
+	CrawledAt      time.Time `db:"crawled_at"`
+	Score          int32     `db:"score"`
+	Ping           int32     `db:"ping"`
+	Speed          int64     `db:"speed"`
+	NumVPNSessions int32     `db:"num_vpn_sessions"`
+	TotalUsers     int32     `db:"total_users"`
+}
+
+// ServerRule entity allows or denies the VPN servers matching all of its non empty criteria
+type ServerRule struct {
+	ID     int32  `db:"id"`
+	Action string `db:"action"`
+	// IPCIDR is a single IP address or a CIDR
+	IPCIDR string `db:"ip_cidr"`
+	// HostNamePattern and OperatorPattern are case insensitive glob patterns e.g. *.opengw.net
+	HostNamePattern string     `db:"host_name_pattern"`
+	OperatorPattern string     `db:"operator_pattern"`
+	CountryCode     string     `db:"country_code"`
+	Comment         string     `db:"comment"`
+	ExpiresAt       *time.Time `db:"expires_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// ServerRulesVersion changes whenever a server rule is created, updated or deleted
+type ServerRulesVersion struct {
+	Count int64 `db:"count"`
+	MaxID int64 `db:"max_id"`
+	// UpdatedAt is the latest update time of the rules as returned by the database
+	UpdatedAt sql.NullString `db:"updated_at"`
+}
@@ -0,0 +1,128 @@
+package vpn
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// crawlRunRunning is status of a crawl that did not finish yet
+	crawlRunRunning = "running"
+	// crawlRunSucceeded is status of a crawl whose servers were persisted
+	crawlRunSucceeded = "succeeded"
+	// crawlRunFailed is status of a crawl that failed with an error
+	crawlRunFailed = "failed"
+	// crawlRunRejected is status of a crawl refused by the crawl guard, the previous servers are kept
+	crawlRunRejected = "rejected"
+	// crawlRunSkipped is status of a crawl whose feed did not change since the previous crawl
+	crawlRunSkipped = "skipped"
+
+	// guardCheckMaxDrop is name of the check on the decrease of the number of servers
+	guardCheckMaxDrop = "max drop"
+)
+
+// AnomalyError is returned for a crawl refused by the crawl guard
+type AnomalyError struct {
+	// Check is name of the failing check
+	Check  string
+	Detail string
+}
+
+func (e *AnomalyError) Error() string {
+	return "crawl rejected by " + e.Check + " check: " + e.Detail
+}
+
+// CrawlGuard holds the sanity checks a crawl passes before it replaces the servers of its source,
+// a zero threshold disables its check
+type CrawlGuard struct {
+	// MinServers is the least number of servers a crawl has
+	MinServers int
+	// MaxDropPercent is the largest decrease of the number of servers relative to the previous crawl
+	MaxDropPercent float64
+	// MaxDropRejections is the number of consecutive crawls rejected by the max drop check after which
+	// the drop is accepted as a lasting shrink of the feed
+	MaxDropRejections int
+	// MinCountries is the least number of distinct countries a crawl has
+	MinCountries int
+	// MaxRejectedRatio is the largest ratio of feed rows rejected by the parser
+	MaxRejectedRatio float64
+}
+
+// CrawlCounts are the counters of a crawl the crawl guard checks besides its servers
+type CrawlCounts struct {
+	// Rows and Rejected are the numbers of feed rows and of rows rejected by the parser
+	Rows     int
+	Rejected int
+	// Previous is the number of servers of the source before the crawl
+	Previous int64
+	// DropRejections is the number of consecutive crawls of the source rejected by the max drop check
+	DropRejections int
+}
+
+// Check returns an AnomalyError when a crawl of the given servers fails a check
+func (g CrawlGuard) Check(servers []*VPNServer, counts CrawlCounts) error {
+	feed := len(servers)
+	if g.MinServers > 0 && feed < g.MinServers {
+		return &AnomalyError{
+			Check:  "min servers",
+			Detail: strconv.Itoa(feed) + " servers, expected at least " + strconv.Itoa(g.MinServers),
+		}
+	}
+	lasting := g.MaxDropRejections > 0 && counts.DropRejections >= g.MaxDropRejections
+	if g.MaxDropPercent > 0 && counts.Previous > 0 && !lasting {
+		previous := counts.Previous
+		drop := float64(previous-int64(feed)) * 100 / float64(previous)
+		if drop > g.MaxDropPercent {
+			return &AnomalyError{
+				Check: guardCheckMaxDrop,
+				Detail: strconv.Itoa(feed) + " servers instead of " + strconv.FormatInt(previous, 10) +
+					", a drop of " + strconv.FormatFloat(drop, 'f', 1, 64) + "% above " +
+					strconv.FormatFloat(g.MaxDropPercent, 'f', -1, 64) + "%",
+			}
+		}
+	}
+	if g.MinCountries > 0 {
+		countries := make(map[string]bool)
+		for _, srv := range servers {
+			countries[srv.Country.Code] = true
+		}
+		if len(countries) < g.MinCountries {
+			return &AnomalyError{
+				Check:  "min countries",
+				Detail: strconv.Itoa(len(countries)) + " countries, expected at least " + strconv.Itoa(g.MinCountries),
+			}
+		}
+	}
+	if g.MaxRejectedRatio > 0 && counts.Rows > 0 {
+		ratio := float64(counts.Rejected) / float64(counts.Rows)
+		if ratio > g.MaxRejectedRatio {
+			return &AnomalyError{
+				Check: "parse errors",
+				Detail: strconv.Itoa(counts.Rejected) + " of " + strconv.Itoa(counts.Rows) + " rows rejected, ratio " +
+					strconv.FormatFloat(ratio, 'f', 2, 64) + " above " + strconv.FormatFloat(g.MaxRejectedRatio, 'f', -1, 64),
+			}
+		}
+	}
+	return nil
+}
+
+// dropRejections returns the number of consecutive runs rejected by the max drop check at the start
+// of runs, which are ordered from the newest. Skipped and failed runs did not check the servers, they
+// do not end the streak.
+func dropRejections(runs []*CrawlRun) int {
+	prefix := (&AnomalyError{Check: guardCheckMaxDrop}).Error()
+	n := 0
+	for _, run := range runs {
+		switch run.Status {
+		case crawlRunSkipped, crawlRunFailed, crawlRunRunning:
+			continue
+		case crawlRunRejected:
+			if strings.HasPrefix(run.Error, prefix) {
+				n++
+				continue
+			}
+		}
+		break
+	}
+	return n
+}
@@ -0,0 +1,145 @@
+package vpn
+
+import (
+	"testing"
+)
+
+func Test_CrawlGuard_Check(t *testing.T) {
+	guard := CrawlGuard{MinServers: 3, MaxDropPercent: 50, MinCountries: 2, MaxRejectedRatio: 0.2}
+	servers := func(codes ...string) []*VPNServer {
+		var res []*VPNServer
+		for _, code := range codes {
+			res = append(res, &VPNServer{Country: Country{Code: code}})
+		}
+		return res
+	}
+	type args struct {
+		servers []*VPNServer
+		counts  CrawlCounts
+	}
+	tests := []struct {
+		name      string
+		guard     CrawlGuard
+		args      args
+		wantCheck string
+	}{
+		{
+			"Healthy crawl should pass",
+			guard,
+			args{servers("JP", "JP", "KR", "US"), CrawlCounts{Rows: 5, Rejected: 1, Previous: 6}},
+			"",
+		},
+		{
+			"Too few servers should be rejected",
+			guard,
+			args{servers("JP", "KR"), CrawlCounts{Rows: 2, Rejected: 0, Previous: 0}},
+			"min servers",
+		},
+		{
+			"Drop above the maximum should be rejected",
+			guard,
+			args{servers("JP", "KR", "US"), CrawlCounts{Rows: 3, Rejected: 0, Previous: 10}},
+			"max drop",
+		},
+		{
+			"Drop without previous servers should pass",
+			guard,
+			args{servers("JP", "KR", "US"), CrawlCounts{Rows: 3, Rejected: 0, Previous: 0}},
+			"",
+		},
+		{
+			"Drop rejected by as many crawls as the maximum should pass",
+			CrawlGuard{MaxDropPercent: 50, MaxDropRejections: 2},
+			args{servers("JP", "KR", "US"), CrawlCounts{Rows: 3, Previous: 10, DropRejections: 2}},
+			"",
+		},
+		{
+			"Drop rejected by fewer crawls than the maximum should be rejected",
+			CrawlGuard{MaxDropPercent: 50, MaxDropRejections: 2},
+			args{servers("JP", "KR", "US"), CrawlCounts{Rows: 3, Previous: 10, DropRejections: 1}},
+			"max drop",
+		},
+		{
+			"Too few countries should be rejected",
+			guard,
+			args{servers("JP", "JP", "JP"), CrawlCounts{Rows: 3, Rejected: 0, Previous: 3}},
+			"min countries",
+		},
+		{
+			"Parse error ratio above the maximum should be rejected",
+			guard,
+			args{servers("JP", "KR", "US"), CrawlCounts{Rows: 5, Rejected: 2, Previous: 3}},
+			"parse errors",
+		},
+		{
+			"Zero thresholds should disable every check",
+			CrawlGuard{},
+			args{nil, CrawlCounts{Rows: 10, Rejected: 10, Previous: 100}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.guard.Check(tt.args.servers, tt.args.counts)
+			if len(tt.wantCheck) == 0 {
+				if err != nil {
+					t.Errorf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+			anomaly, ok := err.(*AnomalyError)
+			if !ok {
+				t.Fatalf("Check() error = %v, want an AnomalyError", err)
+			}
+			if anomaly.Check != tt.wantCheck {
+				t.Errorf("Check() check = %s, want %s", anomaly.Check, tt.wantCheck)
+			}
+		})
+	}
+}
+
+func Test_dropRejections(t *testing.T) {
+	maxDrop := (&AnomalyError{Check: guardCheckMaxDrop, Detail: "3 servers instead of 10"}).Error()
+	minServers := (&AnomalyError{Check: "min servers", Detail: "2 servers"}).Error()
+	tests := []struct {
+		name string
+		runs []*CrawlRun
+		want int
+	}{
+		{
+			"Consecutive max drop rejections should be counted",
+			[]*CrawlRun{
+				{Status: crawlRunRunning},
+				{Status: crawlRunRejected, Error: maxDrop},
+				{Status: crawlRunSkipped},
+				{Status: crawlRunFailed, Error: "timeout"},
+				{Status: crawlRunRejected, Error: maxDrop},
+			},
+			2,
+		},
+		{
+			"Accepted crawl should end the streak",
+			[]*CrawlRun{
+				{Status: crawlRunRejected, Error: maxDrop},
+				{Status: crawlRunSucceeded},
+				{Status: crawlRunRejected, Error: maxDrop},
+			},
+			1,
+		},
+		{
+			"Rejection by another check should end the streak",
+			[]*CrawlRun{
+				{Status: crawlRunRejected, Error: minServers},
+				{Status: crawlRunRejected, Error: maxDrop},
+			},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dropRejections(tt.runs); got != tt.want {
+				t.Errorf("dropRejections() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package vpn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"squirrel-srv/pkg/logger"
+	"sync"
+	"time"
+)
+
+const (
+	// schedulerLease is name of the lease held by the replica running scheduled jobs
+	schedulerLease = "scheduler"
+)
+
+// Elector elects a single leader among the replicas sharing a database through a lease.
+// The leader renews the lease every third of its TTL, when the leader dies the lease
+// expires and another replica takes it over.
+type Elector struct {
+	repo   Repository
+	name   string
+	holder string
+	ttl    time.Duration
+
+	mu sync.RWMutex
+	// deadline is when the lease held by this replica expires, zero when it is not the leader
+	deadline time.Time
+	// term is done when the leadership of this replica ends, nil before it is first elected
+	term    context.Context
+	endTerm context.CancelFunc
+	// expiry ends the term when the lease expires before it could be renewed
+	expiry *time.Timer
+}
+
+// IsLeader reports whether this replica holds an unexpired lease
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return time.Now().Before(e.deadline)
+}
+
+// Run campaigns for the lease until ctx is done, then releases it
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		e.campaign(ctx)
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Term returns a copy of ctx that is also done when this replica loses the lease, so that the work
+// of a former leader stops instead of racing with the new one. ok is false when it is not the leader.
+func (e *Elector) Term(ctx context.Context) (termCtx context.Context, cancel context.CancelFunc, ok bool) {
+	e.mu.RLock()
+	term := e.term
+	leader := time.Now().Before(e.deadline)
+	e.mu.RUnlock()
+	if !leader || term == nil || term.Err() != nil {
+		return nil, nil, false
+	}
+	termCtx, cancel = context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-term.Done():
+			cancel()
+		case <-termCtx.Done():
+		}
+	}()
+	return termCtx, cancel, true
+}
+
+// LeaderOnly wraps a scheduled job so that it only runs on the leader, the job context is done
+// when ctx is done or the lease is lost
+func (e *Elector) LeaderOnly(ctx context.Context, job func(ctx context.Context)) func() {
+	return func() {
+		termCtx, cancel, ok := e.Term(ctx)
+		if !ok {
+			return
+		}
+		defer cancel()
+		job(termCtx)
+	}
+}
+
+func (e *Elector) campaign(ctx context.Context) {
+	// the lease is counted from before the query so the local deadline never outlives the stored one
+	start := time.Now()
+	wasLeader := e.IsLeader()
+	acquired, err := e.repo.AcquireLease(ctx, e.name, e.holder, e.ttl)
+	if err != nil {
+		logger.Log.Warn("acquire " + e.name + " lease error: " + err.Error())
+	}
+
+	e.mu.Lock()
+	if acquired {
+		e.deadline = start.Add(e.ttl)
+		if e.term == nil || e.term.Err() != nil {
+			e.term, e.endTerm = context.WithCancel(context.Background())
+			e.expiry = time.AfterFunc(time.Until(e.deadline), e.endTerm)
+		} else {
+			e.expiry.Reset(time.Until(e.deadline))
+		}
+	} else {
+		e.deadline = time.Time{}
+		e.stopTerm()
+	}
+	e.mu.Unlock()
+
+	if acquired && !wasLeader {
+		logger.Log.Info(e.holder + " became " + e.name + " leader")
+	}
+	if !acquired && wasLeader {
+		logger.Log.Warn(e.holder + " lost " + e.name + " leadership")
+	}
+}
+
+func (e *Elector) resign() {
+	if !e.IsLeader() {
+		return
+	}
+	e.mu.Lock()
+	e.deadline = time.Time{}
+	e.stopTerm()
+	e.mu.Unlock()
+	// ctx is already done when resigning, the release is only bounded by the query timeout
+	if err := e.repo.ReleaseLease(context.Background(), e.name, e.holder); err != nil {
+		logger.Log.Warn("release " + e.name + " lease error: " + err.Error())
+	}
+}
+
+// stopTerm ends the leadership term, e.mu must be held
+func (e *Elector) stopTerm() {
+	if e.term == nil {
+		return
+	}
+	e.expiry.Stop()
+	e.endTerm()
+}
+
+// NewElector creates an elector campaigning for the lease name with a holder id unique to this process
+func NewElector(repo Repository, name string, ttl time.Duration) *Elector {
+	hostname, err := os.Hostname()
+	if hostname == "" || err != nil {
+		hostname = "localhost"
+	}
+	return &Elector{
+		repo:   repo,
+		name:   name,
+		holder: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		ttl:    ttl,
+	}
+}
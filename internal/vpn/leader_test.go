@@ -0,0 +1,91 @@
+package vpn
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_Elector_Term(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		// end ends the leadership of e after its term started, nil keeps it
+		end func(t *testing.T, e *Elector, cancel context.CancelFunc)
+		// wantDone is whether the term context is done after end
+		wantDone bool
+		// wantLeader is whether a new term can be started after end
+		wantLeader bool
+	}{
+		{"Held lease should keep the term", time.Hour, nil, false, true},
+		{
+			"Resign should end the term",
+			time.Hour,
+			func(t *testing.T, e *Elector, _ context.CancelFunc) { e.resign() },
+			true,
+			false,
+		},
+		{
+			"Lease taken over should end the term",
+			time.Hour,
+			func(t *testing.T, e *Elector, _ context.CancelFunc) {
+				if err := e.repo.ReleaseLease(context.Background(), e.name, e.holder); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := e.repo.AcquireLease(context.Background(), e.name, "other", time.Hour); err != nil {
+					t.Fatal(err)
+				}
+				e.campaign(context.Background())
+			},
+			true,
+			false,
+		},
+		{
+			"Lease not renewed should end the term when it expires",
+			50 * time.Millisecond,
+			func(t *testing.T, e *Elector, _ context.CancelFunc) { time.Sleep(100 * time.Millisecond) },
+			true,
+			false,
+		},
+		{
+			"Parent context should end the term",
+			time.Hour,
+			func(t *testing.T, _ *Elector, cancel context.CancelFunc) { cancel() },
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewElector(NewMemoryRepository(), schedulerLease, tt.ttl)
+			if _, _, ok := e.Term(context.Background()); ok {
+				t.Fatal("Term() ok before the election, want not the leader")
+			}
+			e.campaign(context.Background())
+			parent, cancelParent := context.WithCancel(context.Background())
+			defer cancelParent()
+			termCtx, cancel, ok := e.Term(parent)
+			if !ok {
+				t.Fatal("Term() not ok after the election, want the leader")
+			}
+			defer cancel()
+
+			if tt.end != nil {
+				tt.end(t, e, cancelParent)
+			}
+			select {
+			case <-termCtx.Done():
+				if !tt.wantDone {
+					t.Error("Term() context done, want it running")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.wantDone {
+					t.Error("Term() context running, want it done")
+				}
+			}
+			if _, _, ok := e.Term(context.Background()); ok != tt.wantLeader {
+				t.Errorf("Term() ok = %v after the end, want %v", ok, tt.wantLeader)
+			}
+		})
+	}
+}
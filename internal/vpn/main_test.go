@@ -0,0 +1,16 @@
+package vpn
+
+import (
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"squirrel-srv/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	// the crawler, the prober and the scheduled jobs log through the global logger
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,678 @@
+package vpn
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryDriverName is name of the in-memory database driver
+const memoryDriverName = "memory"
+
+// memorySnapshot is a snapshot of the servers of a source
+type memorySnapshot struct {
+	ID     int64
+	Source string
+	Active bool
+}
+
+// memoryLease is a leader election lease
+type memoryLease struct {
+	Holder    string
+	ExpiresAt time.Time
+}
+
+// memoryRepository is a thread-safe in-memory implementation of Repository behaving like mysqlRepository.
+// Entities are copied in and out so callers never share memory with the repository.
+type memoryRepository struct {
+	mu        sync.RWMutex
+	countries []*Country
+	servers   []*VPNServer
+	snapshots []*memorySnapshot
+	crawlRuns []*CrawlRun
+	metrics   []*ServerMetric
+	rules     []*ServerRule
+	leases    map[string]memoryLease
+	// lastIDs holds the last id given per table
+	lastIDs map[string]int64
+}
+
+// memoryTx is a transaction of a memoryRepository. It works on copies of the countries, servers and
+// snapshots and buffers the recorded metrics, the repository takes them over on commit.
+type memoryTx struct {
+	now       time.Time
+	countries []*Country
+	servers   []*VPNServer
+	snapshots []*memorySnapshot
+	metrics   []*ServerMetric
+	lastIDs   map[string]int64
+}
+
+// memoryFixture is the JSON document a memory repository is seeded with
+type memoryFixture struct {
+	// Servers are persisted as a crawl of their source, or of the fixture source when it is empty
+	Servers     []memoryFixtureServer `json:"servers"`
+	ServerRules []*ServerRule         `json:"serverRules"`
+}
+
+// memoryFixtureServer is a VPN server of a fixture, its country is given by name and code
+type memoryFixtureServer struct {
+	*VPNServer
+	CountryName string `json:"countryName"`
+	CountryCode string `json:"countryCode"`
+}
+
+// fixtureSourceName is source of the fixture servers without one
+const fixtureSourceName = "fixture"
+
+// nextID returns the next id of table
+func nextID(lastIDs map[string]int64, table string) int64 {
+	lastIDs[table]++
+	return lastIDs[table]
+}
+
+// withCountry returns a copy of server with the country it references
+func (m *memoryRepository) withCountry(server *VPNServer) *VPNServer {
+	s := *server
+	s.Country = Country{}
+	for _, c := range m.countries {
+		if c.ID == s.CountryID {
+			s.Country = Country{ID: c.ID, Name: c.Name, Code: c.Code}
+			break
+		}
+	}
+	return &s
+}
+
+// findServers copies the servers that are not soft deleted and match keep ordered by speed
+func (m *memoryRepository) findServers(keep func(*VPNServer) bool) []*VPNServer {
+	var servers []*VPNServer
+	for _, s := range m.servers {
+		if s.DeletedAt == nil && keep(s) {
+			servers = append(servers, m.withCountry(s))
+		}
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		return servers[i].Speed > servers[j].Speed
+	})
+	return servers
+}
+
+func (m *memoryRepository) FindCountryByCode(ctx context.Context, code string) (*Country, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, c := range m.countries {
+		if c.Code == code {
+			country := *c
+			return &country, nil
+		}
+	}
+	return nil, ErrCountryNotFound
+}
+
+func (m *memoryRepository) FindAllCountryHaveVPNServer(ctx context.Context) ([]*Country, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	used := make(map[int32]bool)
+	for _, s := range m.servers {
+		if s.DeletedAt == nil {
+			used[s.CountryID] = true
+		}
+	}
+	var countries []*Country
+	for _, c := range m.countries {
+		if used[c.ID] && len(c.Code) > 0 {
+			country := *c
+			countries = append(countries, &country)
+		}
+	}
+	sort.SliceStable(countries, func(i, j int) bool {
+		return countries[i].Name < countries[j].Name
+	})
+	return countries, nil
+}
+
+func (m *memoryRepository) FindVPNServerByCountryCode(ctx context.Context, code string) ([]*VPNServer, error) {
+	country, err := m.FindCountryByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.findServers(func(s *VPNServer) bool {
+		return s.CountryID == country.ID
+	}), nil
+}
+
+func (m *memoryRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.findServers(func(*VPNServer) bool {
+		return true
+	}), nil
+}
+
+func (m *memoryRepository) FindVPNServers(ctx context.Context, query VPNServerQuery) ([]*VPNServer, int64, error) {
+	if len(query.CountryCode) > 0 {
+		if _, err := m.FindCountryByCode(ctx, query.CountryCode); err != nil {
+			return nil, 0, err
+		}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var matches []*VPNServer
+	for _, s := range m.servers {
+		if srv := m.withCountry(s); query.match(srv) {
+			matches = append(matches, srv)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return query.before(query.cursor(matches[i]), query.cursor(matches[j]))
+	})
+	var servers []*VPNServer
+	for _, srv := range matches {
+		if query.After != nil && !query.before(*query.After, query.cursor(srv)) {
+			continue
+		}
+		if query.Limit > 0 && uint64(len(servers)) == query.Limit {
+			break
+		}
+		servers = append(servers, srv)
+	}
+	return servers, int64(len(matches)), nil
+}
+
+func (m *memoryRepository) FindVPNServerByID(ctx context.Context, id int32) (*VPNServer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	servers := m.findServers(func(s *VPNServer) bool {
+		return s.ID == id
+	})
+	if len(servers) == 0 {
+		return nil, ErrVPNServerNotFound
+	}
+	return servers[0], nil
+}
+
+func (m *memoryRepository) FindProbeTargets(ctx context.Context) ([]*VPNServer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var targets []*VPNServer
+	for _, s := range m.servers {
+		if s.DeletedAt == nil {
+			targets = append(targets, &VPNServer{ID: s.ID, IP: s.IP, Protocol: s.Protocol, Port: s.Port})
+		}
+	}
+	return targets, nil
+}
+
+func (m *memoryRepository) CountVPNServers(ctx context.Context, source string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var count int64
+	for _, s := range m.servers {
+		if s.DeletedAt == nil && s.Source == source {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *memoryRepository) UpdateReachability(ctx context.Context, results []Reachability) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	byID := make(map[int32]*VPNServer, len(m.servers))
+	for _, s := range m.servers {
+		byID[s.ID] = s
+	}
+	for _, r := range results {
+		s, ok := byID[r.ServerID]
+		if !ok {
+			continue
+		}
+		s.Reachable = r.Reachable
+		s.ProbeLatencyMs = int32(r.Latency.Milliseconds())
+		if r.Reachable {
+			s.ProbeFailures = 0
+		} else {
+			s.ProbeFailures++
+		}
+		probedAt := now
+		s.ProbedAt = &probedAt
+		s.UpdatedAt = now
+	}
+	return nil
+}
+
+func (m *memoryRepository) CreateServerRule(ctx context.Context, rule ServerRule) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rule.ID = int32(nextID(m.lastIDs, "server_rules"))
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	m.rules = append(m.rules, &rule)
+	return int64(rule.ID), nil
+}
+
+func (m *memoryRepository) FindServerRuleByID(ctx context.Context, id int32) (*ServerRule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, r := range m.rules {
+		if r.ID == id {
+			rule := *r
+			return &rule, nil
+		}
+	}
+	return nil, ErrServerRuleNotFound
+}
+
+func (m *memoryRepository) FindServerRules(ctx context.Context) ([]*ServerRule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var rules []*ServerRule
+	for _, r := range m.rules {
+		rule := *r
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
+
+func (m *memoryRepository) DeleteServerRule(ctx context.Context, id int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, r := range m.rules {
+		if r.ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return ErrServerRuleNotFound
+}
+
+func (m *memoryRepository) FindRuleTargets(ctx context.Context) ([]*VPNServer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var targets []*VPNServer
+	for _, s := range m.servers {
+		if s.DeletedAt == nil {
+			srv := m.withCountry(s)
+			targets = append(targets, &VPNServer{
+				ID:       srv.ID,
+				IP:       srv.IP,
+				HostName: srv.HostName,
+				Operator: srv.Operator,
+				Country:  Country{Code: srv.Country.Code},
+			})
+		}
+	}
+	return targets, nil
+}
+
+func (m *memoryRepository) FindServerRulesVersion(ctx context.Context) (ServerRulesVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	version := ServerRulesVersion{Count: int64(len(m.rules))}
+	var updatedAt time.Time
+	for _, r := range m.rules {
+		if int64(r.ID) > version.MaxID {
+			version.MaxID = int64(r.ID)
+		}
+		if r.UpdatedAt.After(updatedAt) {
+			updatedAt = r.UpdatedAt
+		}
+	}
+	if len(m.rules) > 0 {
+		version.UpdatedAt = sql.NullString{String: updatedAt.Format(time.RFC3339Nano), Valid: true}
+	}
+	return version, nil
+}
+
+func (m *memoryRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx := &memoryTx{
+		now:     time.Now(),
+		lastIDs: make(map[string]int64, len(m.lastIDs)),
+	}
+	for _, c := range m.countries {
+		country := *c
+		tx.countries = append(tx.countries, &country)
+	}
+	for _, s := range m.servers {
+		server := *s
+		tx.servers = append(tx.servers, &server)
+	}
+	for _, s := range m.snapshots {
+		snapshot := *s
+		tx.snapshots = append(tx.snapshots, &snapshot)
+	}
+	for table, id := range m.lastIDs {
+		tx.lastIDs[table] = id
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	// like a database transaction, the changes are dropped when ctx is done before the commit
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.countries = tx.countries
+	m.servers = tx.servers
+	m.snapshots = tx.snapshots
+	m.metrics = append(m.metrics, tx.metrics...)
+	m.lastIDs = tx.lastIDs
+	return nil
+}
+
+func (m *memoryRepository) PurgeSnapshots(ctx context.Context, source string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.snapshots[:0]
+	for _, s := range m.snapshots {
+		if s.Source != source || s.Active {
+			kept = append(kept, s)
+		}
+	}
+	m.snapshots = kept
+	return nil
+}
+
+func (m *memoryRepository) CreateCrawlRun(ctx context.Context, run CrawlRun) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	run.ID = int32(nextID(m.lastIDs, "crawl_runs"))
+	run.CreatedAt = time.Now()
+	run.UpdatedAt = run.CreatedAt
+	m.crawlRuns = append(m.crawlRuns, &run)
+	return int64(run.ID), nil
+}
+
+func (m *memoryRepository) UpdateCrawlRun(ctx context.Context, run CrawlRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.crawlRuns {
+		if r.ID == run.ID {
+			run.Source = r.Source
+			run.StartedAt = r.StartedAt
+			run.CreatedAt = r.CreatedAt
+			run.UpdatedAt = time.Now()
+			*r = run
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) FindCrawlRunByID(ctx context.Context, id int32) (*CrawlRun, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, r := range m.crawlRuns {
+		if r.ID == id {
+			run := *r
+			return &run, nil
+		}
+	}
+	return nil, ErrCrawlRunNotFound
+}
+
+func (m *memoryRepository) FindCrawlRuns(ctx context.Context, source string, limit uint64) ([]*CrawlRun, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var runs []*CrawlRun
+	for _, r := range m.crawlRuns {
+		if len(source) == 0 || r.Source == source {
+			run := *r
+			runs = append(runs, &run)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].StartedAt.Equal(runs[j].StartedAt) {
+			return runs[i].StartedAt.After(runs[j].StartedAt)
+		}
+		return runs[i].ID > runs[j].ID
+	})
+	if uint64(len(runs)) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+func (m *memoryRepository) FindServerMetrics(ctx context.Context, serverID int32, from, to time.Time) ([]*ServerMetric, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var metrics []*ServerMetric
+	for _, p := range m.metrics {
+		if p.ServerID == serverID && !p.CrawledAt.Before(from) && p.CrawledAt.Before(to) {
+			metric := *p
+			metrics = append(metrics, &metric)
+		}
+	}
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].CrawledAt.Before(metrics[j].CrawledAt)
+	})
+	return metrics, nil
+}
+
+func (m *memoryRepository) PruneServerMetrics(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.metrics[:0]
+	for _, p := range m.metrics {
+		if !p.CrawledAt.Before(before) {
+			kept = append(kept, p)
+		}
+	}
+	pruned := int64(len(m.metrics) - len(kept))
+	m.metrics = kept
+	return pruned, nil
+}
+
+func (m *memoryRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	lease, ok := m.leases[name]
+	if !ok || lease.Holder == holder || lease.ExpiresAt.Before(now) {
+		m.leases[name] = memoryLease{Holder: holder, ExpiresAt: now.Add(ttl)}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *memoryRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lease, ok := m.leases[name]; ok && lease.Holder == holder {
+		delete(m.leases, name)
+	}
+	return nil
+}
+
+func (t *memoryTx) ResolveCountries(ctx context.Context, countries []Country) (map[string]int32, error) {
+	ids := make(map[string]int32)
+	for _, c := range t.countries {
+		ids[c.Code] = c.ID
+	}
+	resolved := make(map[string]int32)
+	for _, c := range countries {
+		id, ok := ids[c.Code]
+		if !ok {
+			id = int32(nextID(t.lastIDs, "countries"))
+			t.countries = append(t.countries, &Country{
+				ID:        id,
+				Name:      c.Name,
+				Code:      c.Code,
+				CreatedAt: t.now,
+				UpdatedAt: t.now,
+			})
+			ids[c.Code] = id
+		}
+		resolved[c.Code] = id
+	}
+	return resolved, nil
+}
+
+func (t *memoryTx) CreateSnapshot(ctx context.Context, source string) (int64, error) {
+	id := nextID(t.lastIDs, "snapshots")
+	t.snapshots = append(t.snapshots, &memorySnapshot{ID: id, Source: source})
+	return id, nil
+}
+
+func (t *memoryTx) UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error) {
+	type key struct {
+		source, ip, hostName string
+	}
+	existing := make(map[key]*VPNServer, len(t.servers))
+	for _, s := range t.servers {
+		existing[key{s.Source, s.IP, s.HostName}] = s
+	}
+	var inserted, updated int64
+	for _, server := range servers {
+		s := *server
+		s.Country = Country{}
+		s.LastSeenAt = t.now
+		s.UpdatedAt = t.now
+		s.DeletedAt = nil
+		if old, ok := existing[key{s.Source, s.IP, s.HostName}]; ok {
+			// the probe results are kept like the columns the MySQL upsert does not update
+			s.ID = old.ID
+			s.CreatedAt = old.CreatedAt
+			s.Reachable = old.Reachable
+			s.ProbeLatencyMs = old.ProbeLatencyMs
+			s.ProbeFailures = old.ProbeFailures
+			s.ProbedAt = old.ProbedAt
+			*old = s
+			updated++
+			continue
+		}
+		s.ID = int32(nextID(t.lastIDs, "vpn_servers"))
+		s.CreatedAt = t.now
+		s.Reachable = false
+		s.ProbeLatencyMs = 0
+		s.ProbeFailures = 0
+		s.ProbedAt = nil
+		t.servers = append(t.servers, &s)
+		existing[key{s.Source, s.IP, s.HostName}] = &s
+		inserted++
+	}
+	return inserted, updated, nil
+}
+
+func (t *memoryTx) DeleteMissing(ctx context.Context, source string, snapshotID int64) (int64, error) {
+	var removed int64
+	for _, s := range t.servers {
+		if s.Source == source && s.DeletedAt == nil && int64(s.SnapshotID) != snapshotID {
+			deletedAt := t.now
+			s.DeletedAt = &deletedAt
+			s.UpdatedAt = t.now
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (t *memoryTx) RecordMetrics(ctx context.Context, snapshotID int64) (int64, error) {
+	var recorded int64
+	for _, s := range t.servers {
+		if int64(s.SnapshotID) != snapshotID || s.DeletedAt != nil {
+			continue
+		}
+		t.metrics = append(t.metrics, &ServerMetric{
+			ID:             nextID(t.lastIDs, "server_metrics"),
+			ServerID:       s.ID,
+			CrawledAt:      t.now,
+			Score:          s.Score,
+			Ping:           s.Ping,
+			Speed:          s.Speed,
+			NumVPNSessions: s.NumVPNSessions,
+			TotalUsers:     s.TotalUsers,
+		})
+		recorded++
+	}
+	return recorded, nil
+}
+
+func (t *memoryTx) ActivateSnapshot(ctx context.Context, source string, id int64) error {
+	for _, s := range t.snapshots {
+		if s.Source == source {
+			s.Active = s.ID == id
+		}
+	}
+	return nil
+}
+
+// seedMemoryRepository persists the servers and server rules of a JSON fixture file in repo
+func seedMemoryRepository(ctx context.Context, repo Repository, path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fixture := memoryFixture{}
+	if err := json.Unmarshal(raw, &fixture); err != nil {
+		return err
+	}
+	for _, rule := range fixture.ServerRules {
+		if _, err := repo.CreateServerRule(ctx, *rule); err != nil {
+			return err
+		}
+	}
+
+	bySource := make(map[string][]*VPNServer)
+	var sources []string
+	for _, fs := range fixture.Servers {
+		if fs.VPNServer == nil {
+			continue
+		}
+		srv := fs.VPNServer
+		srv.Country = Country{Name: fs.CountryName, Code: fs.CountryCode}
+		if len(srv.Source) == 0 {
+			srv.Source = fixtureSourceName
+		}
+		if _, ok := bySource[srv.Source]; !ok {
+			sources = append(sources, srv.Source)
+		}
+		bySource[srv.Source] = append(bySource[srv.Source], srv)
+	}
+	for _, source := range sources {
+		servers := bySource[source]
+		err := repo.Transaction(ctx, func(tx Tx) error {
+			snapshotID, err := tx.CreateSnapshot(ctx, source)
+			if err != nil {
+				return err
+			}
+			countries := make([]Country, 0, len(servers))
+			for _, srv := range servers {
+				countries = append(countries, srv.Country)
+			}
+			countryIDs, err := tx.ResolveCountries(ctx, countries)
+			if err != nil {
+				return err
+			}
+			for _, srv := range servers {
+				srv.CountryID = countryIDs[srv.Country.Code]
+				srv.SnapshotID = int32(snapshotID)
+			}
+			if _, _, err := tx.UpsertBatch(ctx, servers); err != nil {
+				return err
+			}
+			return tx.ActivateSnapshot(ctx, source, snapshotID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		leases:  make(map[string]memoryLease),
+		lastIDs: make(map[string]int64),
+	}
+}
@@ -0,0 +1,80 @@
+package vpn
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_memoryRepository_crawl(t *testing.T) {
+	testRepositoryCrawl(t, NewMemoryRepository())
+}
+
+func Test_memoryRepository_FindVPNServers(t *testing.T) {
+	testRepositoryFindVPNServers(t, NewMemoryRepository())
+}
+
+func Test_memoryRepository_AcquireLease(t *testing.T) {
+	testRepositoryAcquireLease(t, NewMemoryRepository())
+}
+
+func Test_memoryRepository_FindServerRulesVersion(t *testing.T) {
+	testRepositoryFindServerRulesVersion(t, NewMemoryRepository())
+}
+
+func Test_memoryRepository_UpdateReachability(t *testing.T) {
+	testRepositoryUpdateReachability(t, NewMemoryRepository())
+}
+
+func Test_seedMemoryRepository(t *testing.T) {
+	repo := NewMemoryRepository()
+	if err := seedMemoryRepository(context.Background(), repo, "testdata/fixture.json"); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		code      string
+		wantHosts []string
+		wantErr   error
+	}{
+		{"Servers of a country should be ordered by speed", "JP", []string{"public-vpn-1", "public-vpn-3"}, nil},
+		{"Unknown country should be not found", "FR", nil, ErrCountryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, err := repo.FindVPNServerByCountryCode(context.Background(), tt.code)
+			if err != tt.wantErr {
+				t.Fatalf("FindVPNServerByCountryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var hosts []string
+			for _, s := range servers {
+				if s.Source != fixtureSourceName || s.Country.Code != tt.code {
+					t.Errorf("FindVPNServerByCountryCode() server %s of source %s in %s", s.HostName, s.Source, s.Country.Code)
+				}
+				hosts = append(hosts, s.HostName)
+			}
+			if len(hosts) != len(tt.wantHosts) {
+				t.Fatalf("FindVPNServerByCountryCode() = %v, want %v", hosts, tt.wantHosts)
+			}
+			for i := range hosts {
+				if hosts[i] != tt.wantHosts[i] {
+					t.Errorf("FindVPNServerByCountryCode() = %v, want %v", hosts, tt.wantHosts)
+				}
+			}
+		})
+	}
+
+	countries, err := repo.FindAllCountryHaveVPNServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(countries) != 2 || countries[0].Code != "JP" || countries[1].Code != "KR" {
+		t.Errorf("FindAllCountryHaveVPNServer() = %+v, want JP and KR", countries)
+	}
+	rules, err := repo.FindServerRules(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].IPCIDR != "10.0.0.0/8" {
+		t.Errorf("FindServerRules() = %+v, want the fixture rule", rules)
+	}
+}
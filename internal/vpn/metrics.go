@@ -0,0 +1,83 @@
+package vpn
+
+import (
+	"time"
+)
+
+const (
+	// defaultMetricsRange is time range of a metric series when the request has none
+	defaultMetricsRange = 24 * time.Hour
+	// defaultMetricsPoints is number of points of a downsampled series when the request has none
+	defaultMetricsPoints = 100
+	// maxMetricsPoints is the highest number of points of a downsampled series
+	maxMetricsPoints = 1000
+)
+
+// MetricPoint is the average of the metric points of a VPN server within a time bucket
+type MetricPoint struct {
+	// Time is start of the bucket
+	Time           time.Time
+	Score          float64
+	Ping           float64
+	Speed          float64
+	NumVPNSessions float64
+	TotalUsers     float64
+	// Samples is number of points averaged
+	Samples int
+}
+
+// add accumulates a metric into the point, the fields hold sums until average is called
+func (p *MetricPoint) add(m *ServerMetric) {
+	p.Score += float64(m.Score)
+	p.Ping += float64(m.Ping)
+	p.Speed += float64(m.Speed)
+	p.NumVPNSessions += float64(m.NumVPNSessions)
+	p.TotalUsers += float64(m.TotalUsers)
+	p.Samples++
+}
+
+// average turns the accumulated sums into averages
+func (p *MetricPoint) average() {
+	if p.Samples == 0 {
+		return
+	}
+	n := float64(p.Samples)
+	p.Score /= n
+	p.Ping /= n
+	p.Speed /= n
+	p.NumVPNSessions /= n
+	p.TotalUsers /= n
+}
+
+// downsampleMetrics averages metrics ordered by time into at most maxPoints buckets of equal
+// width covering [from, to). Empty buckets are left out. The average of all metrics is returned too.
+func downsampleMetrics(metrics []*ServerMetric, from, to time.Time, maxPoints int) ([]*MetricPoint, *MetricPoint) {
+	total := &MetricPoint{Time: from}
+	if maxPoints <= 0 || !to.After(from) {
+		return nil, total
+	}
+	width := to.Sub(from) / time.Duration(maxPoints)
+	if to.Sub(from)%time.Duration(maxPoints) != 0 {
+		width++
+	}
+
+	var points []*MetricPoint
+	var current *MetricPoint
+	for _, m := range metrics {
+		if m.CrawledAt.Before(from) || !m.CrawledAt.Before(to) {
+			continue
+		}
+		start := from.Add(m.CrawledAt.Sub(from) / width * width)
+		if current == nil || !current.Time.Equal(start) {
+			current = &MetricPoint{Time: start}
+			points = append(points, current)
+		}
+		current.add(m)
+		total.add(m)
+	}
+	for _, p := range points {
+		p.average()
+	}
+	total.average()
+	return points, total
+}
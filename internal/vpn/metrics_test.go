@@ -0,0 +1,59 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_downsampleMetrics(t *testing.T) {
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	metrics := []*ServerMetric{
+		{CrawledAt: from.Add(-time.Minute), Ping: 1000},
+		{CrawledAt: from, Ping: 10, Speed: 100},
+		{CrawledAt: from.Add(10 * time.Minute), Ping: 20, Speed: 300},
+		{CrawledAt: from.Add(45 * time.Minute), Ping: 60, Speed: 200, NumVPNSessions: 3},
+		{CrawledAt: to, Ping: 1000},
+	}
+	type args struct {
+		maxPoints int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantPoints  []*MetricPoint
+		wantAverage *MetricPoint
+	}{
+		{
+			"Two buckets of 30 minutes",
+			args{
+				2,
+			},
+			[]*MetricPoint{
+				{Time: from, Ping: 15, Speed: 200, Samples: 2},
+				{Time: from.Add(30 * time.Minute), Ping: 60, Speed: 200, NumVPNSessions: 3, Samples: 1},
+			},
+			&MetricPoint{Time: from, Ping: 30, Speed: 200, NumVPNSessions: 1, Samples: 3},
+		},
+		{
+			"No points should return empty average",
+			args{
+				0,
+			},
+			nil,
+			&MetricPoint{Time: from},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPoints, gotAverage := downsampleMetrics(metrics, from, to, tt.args.maxPoints)
+			if !reflect.DeepEqual(gotPoints, tt.wantPoints) {
+				t.Errorf("downsampleMetrics() points = %+v, want %+v", gotPoints, tt.wantPoints)
+			}
+			if !reflect.DeepEqual(gotAverage, tt.wantAverage) {
+				t.Errorf("downsampleMetrics() average = %+v, want %+v", gotAverage, tt.wantAverage)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package vpn
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"squirrel-srv/migrations"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/httpfs"
+)
+
+// migrationSource returns the embedded migration set of a database driver
+func migrationSource(driverName string) (source.Driver, error) {
+	dir := "mysql"
+	switch driverName {
+	case sqliteDriverName:
+		dir = "sqlite"
+	case postgresDriverName:
+		dir = "postgres"
+	}
+	return httpfs.New(http.FS(migrations.FS), dir)
+}
+
+// newMigrate creates a migrate instance applying the embedded migration set of driverName to db
+func newMigrate(db *sql.DB, driverName string) (*migrate.Migrate, error) {
+	var driver database.Driver
+	var err error
+	switch driverName {
+	case sqliteDriverName:
+		driver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	case postgresDriverName:
+		driver, err = postgres.WithInstance(db, &postgres.Config{})
+	default:
+		driver, err = mysql.WithInstance(db, &mysql.Config{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create driver migrate database: %v", err)
+	}
+	src, err := migrationSource(driverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %v", err)
+	}
+	m, err := migrate.NewWithInstance("httpfs", src, driverName, driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate database instance: %v", err)
+	}
+	return m, nil
+}
+
+// openMigrate connects to the configured database and creates its migrate instance,
+// closing the instance closes the connection
+func openMigrate(cfg Config) (*migrate.Migrate, error) {
+	if cfg.DBDriver == memoryDriverName {
+		return nil, errors.New("the memory database has no migrations")
+	}
+	db, err := sql.Open(cfg.DBDriver, databaseDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect migrate database: %v", err)
+	}
+	m, err := newMigrate(db, cfg.DBDriver)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// migrateUp applies every pending migration, a dirty schema is reported instead of being migrated
+func migrateUp(m *migrate.Migrate) error {
+	version, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database schema is dirty at version %d, fix it then run migrate force", version)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+// latestMigration returns the version of the last migration of a database driver
+func latestMigration(driverName string) (uint, error) {
+	src, err := migrationSource(driverName)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// RunMigrate runs a migrate subcommand on the configured database:
+// up [N], down N, status, force V or goto V
+func RunMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: squirrel migrate [flags] up [N] | down N | status | force V | goto V")
+		fs.PrintDefaults()
+	}
+	cfg, err := loadConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("migrate needs a command")
+	}
+	command := fs.Arg(0)
+	var n int
+	switch {
+	case command == "status" || (command == "up" && fs.NArg() == 1):
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return errors.New(command + " takes no argument")
+		}
+	case command == "up" || command == "down" || command == "force" || command == "goto":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return errors.New(command + " needs exactly one number")
+		}
+		if n, err = strconv.Atoi(fs.Arg(1)); err != nil || n < 0 || (n == 0 && command != "force") {
+			return fmt.Errorf("invalid %s argument: '%s'", command, fs.Arg(1))
+		}
+	default:
+		fs.Usage()
+		return errors.New("unknown migrate command '" + command + "'")
+	}
+
+	m, err := openMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch command {
+	case "up":
+		if fs.NArg() == 1 {
+			err = migrateUp(m)
+		} else {
+			err = m.Steps(n)
+		}
+	case "down":
+		err = m.Steps(-n)
+	case "force":
+		err = m.Force(n)
+	case "goto":
+		err = m.Migrate(uint(n))
+	}
+	if err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return err
+	}
+	latest, err := latestMigration(cfg.DBDriver)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d, dirty: %t, latest: %d\n", version, dirty, latest)
+	return nil
+}
@@ -0,0 +1,51 @@
+package vpn
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func Test_migrateUp(t *testing.T) {
+	db, err := sqlx.Connect(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	m, err := newMigrate(db.DB, sqliteDriverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest, err := latestMigration(sqliteDriverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dirty   bool
+		wantErr bool
+	}{
+		{"Every pending migration should be applied", false, false},
+		{"Migrated schema should be left as is", false, false},
+		{"Dirty schema should be refused", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.dirty {
+				db.MustExec("UPDATE schema_migrations SET dirty = 1")
+			}
+			if err := migrateUp(m); (err != nil) != tt.wantErr {
+				t.Fatalf("migrateUp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			version, _, err := m.Version()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != latest {
+				t.Errorf("version = %d, want %d", version, latest)
+			}
+		})
+	}
+}
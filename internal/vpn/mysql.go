@@ -1,177 +1,74 @@
 package vpn
 
 import (
-	"database/sql"
+	"context"
 	sq "github.com/Masterminds/squirrel"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"time"
 )
 
+// mysqlDriverName is name of the MySQL database driver
+const mysqlDriverName = "mysql"
+
 type mysqlRepository struct {
-	db *sqlx.DB
+	sqlRepository
 }
 
-func (m *mysqlRepository) CreateCountry(country Country) (int64, error) {
-	existCountry, err := m.FindCountryByCode(country.Code)
-	if existCountry != nil {
-		return int64(existCountry.ID), nil
-	}
-	if err == ErrCountryNotFound {
-		insert, args, err := sq.Insert("countries").
-			Columns("name", "code").
-			Values(country.Name, country.Code).
-			ToSql()
-		if err != nil {
-			return 0, err
-		}
-		result, err := m.db.Exec(insert, args...)
-		if err != nil {
-			return 0, err
-		}
-		return result.LastInsertId()
-	}
-	return 0, err
+// mysqlTx is a mysqlRepository bound to a transaction
+type mysqlTx struct {
+	sqlTx
 }
 
-func (m *mysqlRepository) FindCountryByCode(code string) (*Country, error) {
-	query, args, err := sq.Select("*").From("countries").Where(sq.Eq{"code": code}).ToSql()
-	if err != nil {
-		return nil, err
-	}
-	c := Country{}
-	if err := m.db.Get(&c, query, args...); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrCountryNotFound
-		}
-		return nil, err
-	}
-	return &c, nil
+func (m *mysqlRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	return m.transaction(ctx, func(tx sqlTx) error {
+		return fn(&mysqlTx{tx})
+	})
 }
 
-func (m *mysqlRepository) FindAllCountryHaveVPNServer() ([]*Country, error) {
-	query, args, err := sq.Select("countries.*").
-		Distinct().
-		From("countries").
-		LeftJoin("vpn_servers on vpn_servers.country_id = countries.id").
-		Where(sq.NotEq{"code": ""}).
-		OrderBy("countries.name").
-		ToSql()
-	if err != nil {
-		return nil, err
-	}
-	var countries []*Country
-	rows, err := m.db.Queryx(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	for rows.Next() {
-		c := Country{}
-		err := rows.StructScan(&c)
-		if err == nil {
-			countries = append(countries, &c)
-		}
-	}
-	return countries, nil
+func (m *mysqlRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	// the database clock is the only one compared so replicas do not depend on their own clocks,
+	// holder is assigned first so that expires_at is only moved by the lease owner
+	upsert := m.builder().Insert("leader_leases").
+		Columns("name", "holder", "expires_at").
+		Values(name, holder, sq.Expr("NOW(3) + INTERVAL ? MICROSECOND", ttl.Microseconds())).
+		Suffix(`ON DUPLICATE KEY UPDATE
+			holder = IF(holder = VALUES(holder) OR expires_at < NOW(3), VALUES(holder), holder),
+			expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)`)
+	return m.acquireLease(ctx, name, holder, upsert)
 }
 
-func (m *mysqlRepository) Create(server VPNServer) (int64, error) {
-	insert, args, err := sq.Insert("vpn_servers").
-		Columns("host_name",
-			"ip",
-			"score",
-			"ping",
-			"speed",
-			"country_id",
-			"num_vpn_sessions",
-			"uptime",
-			"total_users",
-			"total_traffic",
-			"log_type",
-			"operator",
-			"message",
-			"open_vpn_config").
-		Values(server.HostName,
-			server.IP,
-			server.Score,
-			server.Ping,
-			server.Speed,
-			server.CountryID,
-			server.NumVPNSessions,
-			server.Uptime,
-			server.TotalUsers,
-			server.TotalTraffic,
-			server.LogType,
-			server.Operator,
-			server.Message,
-			server.OpenVPNConfig).
-		ToSql()
-	if err != nil {
-		return 0, err
-	}
-	result, err := m.db.Exec(insert, args...)
-	if err != nil {
-		return 0, err
+func (t *mysqlTx) UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error) {
+	if len(servers) == 0 {
+		return 0, 0, nil
 	}
-	return result.LastInsertId()
-}
-
-func (m *mysqlRepository) FindVPNServerByCountryCode(code string) ([]*VPNServer, error) {
-	country, err := m.FindCountryByCode(code)
-	if err != nil {
-		return nil, err
-	}
-	query, args, err := sq.Select(`vpn_servers.*,
-		countries.name "country.name",
-		countries.code "country.code",
-		countries.id "country.id"`).
-		Distinct().
-		From("vpn_servers").
-		LeftJoin("countries on countries.id = vpn_servers.country_id").
-		Where(sq.Eq{"country_id": country.ID}).
-		OrderBy("vpn_servers.speed desc").
+	smt, args, err := t.insertServers(servers).
+		Suffix("ON DUPLICATE KEY UPDATE\n\t\t\t" + upsertServerAssignments("VALUES(%s)")).
 		ToSql()
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
-	var vpnServers []*VPNServer
-	err = m.db.Select(&vpnServers, query, args...)
+	result, err := t.tx.ExecContext(ctx, smt, args...)
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
-	return vpnServers, nil
-}
-
-func (m *mysqlRepository) FindAllVPNServer() ([]*VPNServer, error) {
-	query, args, err := sq.Select(`vpn_servers.*,
-		countries.name "country.name",
-		countries.code "country.code",
-		countries.id "country.id"`).
-		Distinct().
-		From("vpn_servers").
-		LeftJoin("countries on countries.id = vpn_servers.country_id").
-		OrderBy("vpn_servers.speed desc").
-		ToSql()
+	// MySQL reports 1 affected row for an insert and 2 for an update of an existing row,
+	// every update changes at least snapshot_id so no row is reported unchanged
+	affected, err := result.RowsAffected()
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
-	var vpnServers []*VPNServer
-	err = m.db.Select(&vpnServers, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	return vpnServers, nil
-}
-
-func (m *mysqlRepository) Truncate() error {
-	smt := `TRUNCATE TABLE vpn_servers`
-	_, err := m.db.Exec(smt)
-	if err != nil {
-		return err
-	}
-	return nil
-
+	n := int64(len(servers))
+	return 2*n - affected, affected - n, nil
 }
 
+// NewRepository creates the repository matching the driver of db
 func NewRepository(db *sqlx.DB) Repository {
-	return &mysqlRepository{db}
+	switch db.DriverName() {
+	case sqliteDriverName:
+		return &sqliteRepository{sqlRepository{db, sqlDialect{sq.Question, applicationNow}}}
+	case postgresDriverName:
+		return &postgresRepository{sqlRepository{db, sqlDialect{sq.Dollar, databaseNow}}}
+	}
+	return &mysqlRepository{sqlRepository{db, sqlDialect{sq.Question, databaseNow}}}
 }
@@ -0,0 +1,64 @@
+package vpn
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+)
+
+// kEnvTestMySQLDSN is the environment variable holding the DSN of a disposable MySQL database,
+// the MySQL tests are skipped when it is empty
+const kEnvTestMySQLDSN = "TEST_MYSQL_DSN"
+
+// newTestMySQLRepository creates a repository backed by a freshly migrated MySQL database
+func newTestMySQLRepository(t *testing.T) Repository {
+	dsn := os.Getenv(kEnvTestMySQLDSN)
+	if len(dsn) == 0 {
+		t.Skip(kEnvTestMySQLDSN + " is not set")
+	}
+	// the repository scans times and the migrations run several statements at once like databaseDSN sets up
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ParseTime = true
+	cfg.MultiStatements = true
+	db, err := sqlx.Connect(mysqlDriverName, cfg.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := newMigrate(db.DB, mysqlDriverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Down() })
+	return NewRepository(db)
+}
+
+func Test_mysqlRepository_crawl(t *testing.T) {
+	testRepositoryCrawl(t, newTestMySQLRepository(t))
+}
+
+func Test_mysqlRepository_FindVPNServers(t *testing.T) {
+	testRepositoryFindVPNServers(t, newTestMySQLRepository(t))
+}
+
+func Test_mysqlRepository_AcquireLease(t *testing.T) {
+	testRepositoryAcquireLease(t, newTestMySQLRepository(t))
+}
+
+func Test_mysqlRepository_FindServerRulesVersion(t *testing.T) {
+	testRepositoryFindServerRulesVersion(t, newTestMySQLRepository(t))
+}
+
+func Test_mysqlRepository_UpdateReachability(t *testing.T) {
+	testRepositoryUpdateReachability(t, newTestMySQLRepository(t))
+}
@@ -0,0 +1,42 @@
+package vpn
+
+import (
+	"math"
+	"sort"
+	"squirrel-srv/pkg/geoip"
+)
+
+// nearestDistanceBucketKm is the width of the distance buckets of nearest servers,
+// servers in the same bucket are ordered by rank
+const nearestDistanceBucketKm = 500
+
+// NearestServer is a ranked VPN server with its distance to the client
+type NearestServer struct {
+	*RankedServer
+	// DistanceKm is -1 when the server location is unknown
+	DistanceKm float64
+}
+
+// sortNearest orders ranked servers by distance to lat, lon in buckets of nearestDistanceBucketKm,
+// then by rank within a bucket. Servers without a location go last.
+func sortNearest(ranked []*RankedServer, lat, lon float64) []*NearestServer {
+	nearest := make([]*NearestServer, len(ranked))
+	for i, r := range ranked {
+		distance := -1.0
+		if r.Latitude != nil && r.Longitude != nil {
+			distance = geoip.Distance(lat, lon, *r.Latitude, *r.Longitude)
+		}
+		nearest[i] = &NearestServer{RankedServer: r, DistanceKm: distance}
+	}
+	bucket := func(n *NearestServer) float64 {
+		if n.DistanceKm < 0 {
+			return math.Inf(1)
+		}
+		return math.Floor(n.DistanceKm / nearestDistanceBucketKm)
+	}
+	// ranked is best first, a stable sort keeps that order within a bucket
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return bucket(nearest[i]) < bucket(nearest[j])
+	})
+	return nearest
+}
@@ -0,0 +1,49 @@
+package vpn
+
+import (
+	"testing"
+)
+
+func Test_sortNearest(t *testing.T) {
+	// the client is in Tokyo
+	lat, lon := 35.6895, 139.6917
+	located := func(id int32, lat, lon float64) *RankedServer {
+		return &RankedServer{VPNServer: &VPNServer{ID: id, Latitude: &lat, Longitude: &lon}}
+	}
+	unknown := func(id int32) *RankedServer {
+		return &RankedServer{VPNServer: &VPNServer{ID: id}}
+	}
+	var (
+		osaka    = located(1, 34.6937, 135.5023)
+		yokohama = located(2, 35.4437, 139.6380)
+		seoul    = located(3, 37.5665, 126.9780)
+		paris    = located(4, 48.8566, 2.3522)
+	)
+	tests := []struct {
+		name   string
+		ranked []*RankedServer
+		want   []int32
+	}{
+		{"Farther buckets should go after nearer ones", []*RankedServer{paris, seoul, osaka}, []int32{1, 3, 4}},
+		{"Same bucket should keep the rank order", []*RankedServer{osaka, yokohama}, []int32{1, 2}},
+		{"Same bucket in the other rank order should keep it", []*RankedServer{yokohama, osaka}, []int32{2, 1}},
+		{"Unknown locations should go last in rank order", []*RankedServer{unknown(5), paris, unknown(6), osaka}, []int32{1, 4, 5, 6}},
+		{"No server should be no server", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortNearest(tt.ranked, lat, lon)
+			if len(got) != len(tt.want) {
+				t.Fatalf("sortNearest() = %d servers, want %d", len(got), len(tt.want))
+			}
+			for i, n := range got {
+				if n.ID != tt.want[i] {
+					t.Errorf("sortNearest()[%d] = server %d, want %d", i, n.ID, tt.want[i])
+				}
+				if (n.Latitude == nil) != (n.DistanceKm < 0) {
+					t.Errorf("sortNearest()[%d] distance = %v, want -1 only for an unknown location", i, n.DistanceKm)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package vpn
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultOpenVPNProtocol is protocol OpenVPN uses when a config does not set one
+	defaultOpenVPNProtocol = "udp"
+	// defaultOpenVPNPort is port OpenVPN uses when a config does not set one
+	defaultOpenVPNPort = 1194
+	// openVPNProfileContentType is content type of .ovpn profiles
+	openVPNProfileContentType = "application/x-openvpn-profile"
+)
+
+var (
+	ErrOpenVPNConfigEmpty    = errors.New("openvpn config is empty")
+	ErrOpenVPNConfigNoRemote = errors.New("openvpn config has no remote")
+	ErrInvalidProfileOption  = errors.New("invalid openvpn profile option")
+)
+
+// clientDirectives are the directives clients are allowed to add to a profile
+var clientDirectives = map[string]bool{
+	"auth-nocache":        true,
+	"auth-retry":          true,
+	"block-outside-dns":   true,
+	"connect-retry":       true,
+	"connect-retry-max":   true,
+	"mssfix":              true,
+	"nobind":              true,
+	"persist-key":         true,
+	"persist-tun":         true,
+	"pull-filter":         true,
+	"rcvbuf":              true,
+	"redirect-gateway":    true,
+	"resolv-retry":        true,
+	"route-nopull":        true,
+	"server-poll-timeout": true,
+	"sndbuf":              true,
+	"tun-mtu":             true,
+	"verb":                true,
+}
+
+// OpenVPNConfig is the connection settings extracted from an OpenVPN client config
+type OpenVPNConfig struct {
+	// Protocol is tcp or udp
+	Protocol string
+	// Port is the port of the first remote
+	Port int32
+	// RemoteHosts are the distinct hosts of the remote directives in order
+	RemoteHosts []string
+	// Cipher is the data channel cipher
+	Cipher string
+	// AuthDigest is the HMAC digest algorithm
+	AuthDigest string
+	// Compression is the compression algorithm, empty when compression is off
+	Compression string
+	// EmbedsCA reports whether the config has an inline <ca> block
+	EmbedsCA bool
+	// EmbedsCert reports whether the config has an inline <cert> block
+	EmbedsCert bool
+	// EmbedsKey reports whether the config has an inline <key> block
+	EmbedsKey bool
+}
+
+// ParseOpenVPNConfig decodes a base64 encoded OpenVPN client config and extracts its connection settings
+func ParseOpenVPNConfig(encoded string) (*OpenVPNConfig, error) {
+	if len(strings.TrimSpace(encoded)) == 0 {
+		return nil, ErrOpenVPNConfigEmpty
+	}
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("openvpn config is not valid base64: %v", err)
+	}
+	return parseOpenVPNDirectives(string(content))
+}
+
+// parseOpenVPNDirectives extracts connection settings from a plain text OpenVPN client config
+func parseOpenVPNDirectives(content string) (*OpenVPNConfig, error) {
+	cfg := &OpenVPNConfig{}
+	var port int32
+	var inline string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	// inline certificates and keys are longer than the default token size of some lines
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(inline) > 0 {
+			if line == "</"+inline+">" {
+				inline = ""
+			}
+			continue
+		}
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "<") && strings.HasSuffix(line, ">") && !strings.HasPrefix(line, "</") {
+			inline = strings.Trim(line, "<>")
+			switch inline {
+			case "ca":
+				cfg.EmbedsCA = true
+			case "cert":
+				cfg.EmbedsCert = true
+			case "key":
+				cfg.EmbedsKey = true
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		args := fields[1:]
+		switch strings.ToLower(fields[0]) {
+		case "proto":
+			if len(args) > 0 && len(cfg.Protocol) == 0 {
+				cfg.Protocol = normalizeOpenVPNProtocol(args[0])
+			}
+		case "port", "rport":
+			if len(args) > 0 && port == 0 {
+				port = parseOpenVPNPort(args[0])
+			}
+		case "remote":
+			if len(args) == 0 {
+				continue
+			}
+			if len(cfg.RemoteHosts) == 0 {
+				if len(args) > 1 {
+					port = parseOpenVPNPort(args[1])
+				}
+				if len(args) > 2 {
+					cfg.Protocol = normalizeOpenVPNProtocol(args[2])
+				}
+			}
+			if !containsString(cfg.RemoteHosts, args[0]) {
+				cfg.RemoteHosts = append(cfg.RemoteHosts, args[0])
+			}
+		case "cipher":
+			if len(args) > 0 {
+				cfg.Cipher = args[0]
+			}
+		case "auth":
+			if len(args) > 0 {
+				cfg.AuthDigest = args[0]
+			}
+		case "comp-lzo":
+			if len(args) == 0 || args[0] != "no" {
+				cfg.Compression = "lzo"
+			}
+		case "compress":
+			if len(args) > 0 {
+				cfg.Compression = args[0]
+			} else {
+				cfg.Compression = "stub"
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("openvpn config is not readable: %v", err)
+	}
+	if len(cfg.RemoteHosts) == 0 {
+		return nil, ErrOpenVPNConfigNoRemote
+	}
+	if len(cfg.Protocol) == 0 {
+		cfg.Protocol = defaultOpenVPNProtocol
+	}
+	if port == 0 {
+		port = defaultOpenVPNPort
+	}
+	cfg.Port = port
+	return cfg, nil
+}
+
+// BuildOpenVPNProfile decodes a base64 encoded OpenVPN config into a ready-to-use profile that
+// connects with the protocol and port of the config. Directives and DNS servers are added as client
+// side options, invalid options are reported with ErrInvalidProfileOption.
+func BuildOpenVPNProfile(encoded string, directives, dns []string) ([]byte, error) {
+	if _, err := ParseOpenVPNConfig(encoded); err != nil {
+		return nil, err
+	}
+	content, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+
+	var options []string
+	for _, directive := range directives {
+		directive = strings.TrimSpace(directive)
+		fields := strings.Fields(directive)
+		if len(fields) == 0 || strings.ContainsAny(directive, "\r\n<>") || !clientDirectives[fields[0]] {
+			return nil, fmt.Errorf("%w: directive '%s' is not allowed", ErrInvalidProfileOption, directive)
+		}
+		options = append(options, strings.Join(fields, " "))
+	}
+	for _, server := range dns {
+		if net.ParseIP(server) == nil {
+			return nil, fmt.Errorf("%w: DNS server '%s' is not an IP address", ErrInvalidProfileOption, server)
+		}
+		options = append(options, "dhcp-option DNS "+server)
+	}
+
+	var profile bytes.Buffer
+	var inline string
+	var optionsWritten bool
+	writeOptions := func() {
+		if optionsWritten || len(options) == 0 {
+			return
+		}
+		profile.WriteString("\n# client options\n")
+		for _, option := range options {
+			profile.WriteString(option + "\n")
+		}
+		profile.WriteString("\n")
+		optionsWritten = true
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if len(inline) > 0 {
+			if trimmed == "</"+inline+">" {
+				inline = ""
+			}
+			profile.WriteString(line + "\n")
+			continue
+		}
+		if strings.HasPrefix(trimmed, "<") && strings.HasSuffix(trimmed, ">") && !strings.HasPrefix(trimmed, "</") {
+			// options go right before the inline certificates and keys
+			writeOptions()
+			inline = strings.Trim(trimmed, "<>")
+			profile.WriteString(line + "\n")
+			continue
+		}
+		profile.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("openvpn config is not readable: %v", err)
+	}
+	writeOptions()
+	return profile.Bytes(), nil
+}
+
+// normalizeOpenVPNProtocol maps protocol variants like tcp-client or udp6 to tcp or udp
+func normalizeOpenVPNProtocol(proto string) string {
+	proto = strings.ToLower(proto)
+	if strings.HasPrefix(proto, "tcp") {
+		return "tcp"
+	}
+	if strings.HasPrefix(proto, "udp") {
+		return "udp"
+	}
+	return proto
+}
+
+// parseOpenVPNPort parses a port number, it returns 0 for invalid ports
+func parseOpenVPNPort(value string) int32 {
+	port, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0
+	}
+	return int32(port)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
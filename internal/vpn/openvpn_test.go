@@ -0,0 +1,198 @@
+package vpn
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseOpenVPNConfig(t *testing.T) {
+	type args struct {
+		config string
+		// encoded tells that config is passed as is instead of being base64 encoded first
+		encoded bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *OpenVPNConfig
+		wantErr bool
+	}{
+		{
+			"Empty config should be error",
+			args{
+				"",
+				false,
+			},
+			nil,
+			true,
+		},
+		{
+			"Invalid base64 should be error",
+			args{
+				"not base64!",
+				true,
+			},
+			nil,
+			true,
+		},
+		{
+			"Config without remote should be error",
+			args{
+				"client\ndev tun\nproto tcp\n",
+				false,
+			},
+			nil,
+			true,
+		},
+		{
+			"VPNGate config",
+			args{
+				"client\ndev tun\nproto tcp\nremote 219.100.37.1 443\n;remote 10.0.0.1 1194\n" +
+					"cipher AES-128-CBC\nauth SHA1\ncomp-lzo\n" +
+					"<ca>\n-----BEGIN CERTIFICATE-----\nremote 10.0.0.2\n-----END CERTIFICATE-----\n</ca>\n" +
+					"<cert>\n</cert>\n<key>\n</key>\n",
+				false,
+			},
+			&OpenVPNConfig{
+				Protocol:    "tcp",
+				Port:        443,
+				RemoteHosts: []string{"219.100.37.1"},
+				Cipher:      "AES-128-CBC",
+				AuthDigest:  "SHA1",
+				Compression: "lzo",
+				EmbedsCA:    true,
+				EmbedsCert:  true,
+				EmbedsKey:   true,
+			},
+			false,
+		},
+		{
+			"Defaults and multiple remotes",
+			args{
+				"client\nremote vpn1.example.com\nremote vpn2.example.com 1195 tcp\nremote vpn1.example.com\ncompress lz4\n",
+				false,
+			},
+			&OpenVPNConfig{
+				Protocol:    "udp",
+				Port:        1194,
+				RemoteHosts: []string{"vpn1.example.com", "vpn2.example.com"},
+				Compression: "lz4",
+			},
+			false,
+		},
+		{
+			"Port directive and client protocol variant",
+			args{
+				"client\nproto tcp-client\nport 995\nremote vpn.example.com\ncomp-lzo no\n",
+				false,
+			},
+			&OpenVPNConfig{
+				Protocol:    "tcp",
+				Port:        995,
+				RemoteHosts: []string{"vpn.example.com"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.args.config
+			if !tt.args.encoded {
+				config = base64.StdEncoding.EncodeToString([]byte(config))
+			}
+			got, err := ParseOpenVPNConfig(config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseOpenVPNConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOpenVPNConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildOpenVPNProfile(t *testing.T) {
+	config := base64.StdEncoding.EncodeToString([]byte(
+		"client\nproto udp\nremote 219.100.37.1 1195 udp\nexplicit-exit-notify\n<ca>\nproto udp\n</ca>\n"))
+	// VPNGate configs often have no proto directive, OpenVPN then uses udp
+	noProtoConfig := base64.StdEncoding.EncodeToString([]byte("client\nremote 219.100.37.1 1194\n"))
+	type args struct {
+		config     string
+		directives []string
+		dns        []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			"Directive out of the allow list should be error",
+			args{
+				config,
+				[]string{"up /bin/sh"},
+				nil,
+			},
+			"",
+			ErrInvalidProfileOption,
+		},
+		{
+			"Invalid DNS server should be error",
+			args{
+				config,
+				nil,
+				[]string{"dns.example.com"},
+			},
+			"",
+			ErrInvalidProfileOption,
+		},
+		{
+			"Unchanged profile",
+			args{
+				config,
+				nil,
+				nil,
+			},
+			"client\nproto udp\nremote 219.100.37.1 1195 udp\nexplicit-exit-notify\n<ca>\nproto udp\n</ca>\n",
+			nil,
+		},
+		{
+			"Client options should go before the inline blocks",
+			args{
+				config,
+				[]string{"auth-nocache"},
+				[]string{"1.1.1.1"},
+			},
+			"client\nproto udp\nremote 219.100.37.1 1195 udp\nexplicit-exit-notify\n" +
+				"\n# client options\nauth-nocache\ndhcp-option DNS 1.1.1.1\n\n" +
+				"<ca>\nproto udp\n</ca>\n",
+			nil,
+		},
+		{
+			"Config without proto should be unchanged",
+			args{
+				noProtoConfig,
+				nil,
+				nil,
+			},
+			"client\nremote 219.100.37.1 1194\n",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildOpenVPNProfile(tt.args.config, tt.args.directives, tt.args.dns)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("BuildOpenVPNProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("BuildOpenVPNProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package vpn
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// postgresDriverName is name of the PostgreSQL database driver
+const postgresDriverName = "postgres"
+
+// postgresRepository is the PostgreSQL implementation of Repository. PostgreSQL has no last insert id,
+// the inserts return the ids of their rows instead.
+type postgresRepository struct {
+	sqlRepository
+}
+
+// postgresTx is a postgresRepository bound to a transaction
+type postgresTx struct {
+	sqlTx
+}
+
+func (p *postgresRepository) CreateServerRule(ctx context.Context, rule ServerRule) (int64, error) {
+	return returningID(ctx, p.db, p.insertServerRule(rule))
+}
+
+func (p *postgresRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	return p.transaction(ctx, func(tx sqlTx) error {
+		return fn(&postgresTx{tx})
+	})
+}
+
+func (p *postgresRepository) CreateCrawlRun(ctx context.Context, run CrawlRun) (int64, error) {
+	return returningID(ctx, p.db, p.insertCrawlRun(run))
+}
+
+func (p *postgresRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	// the database clock is the only one compared so replicas do not depend on their own clocks,
+	// PostgreSQL evaluates every assignment against the old row so both repeat the ownership condition
+	upsert := p.builder().Insert("leader_leases").
+		Columns("name", "holder", "expires_at").
+		Values(name, holder, sq.Expr("NOW() + ? * INTERVAL '1 microsecond'", ttl.Microseconds())).
+		Suffix(`ON CONFLICT (name) DO UPDATE SET
+			holder = CASE WHEN leader_leases.holder = excluded.holder OR leader_leases.expires_at < NOW()
+				THEN excluded.holder ELSE leader_leases.holder END,
+			expires_at = CASE WHEN leader_leases.holder = excluded.holder OR leader_leases.expires_at < NOW()
+				THEN excluded.expires_at ELSE leader_leases.expires_at END`)
+	return p.acquireLease(ctx, name, holder, upsert)
+}
+
+func (t *postgresTx) ResolveCountries(ctx context.Context, countries []Country) (map[string]int32, error) {
+	ids, insert, missing, err := t.missingCountries(ctx, countries)
+	if err != nil || len(missing) == 0 {
+		return ids, err
+	}
+	smt, args, err := insert.Suffix("RETURNING id, code").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var inserted []*Country
+	if err := t.tx.SelectContext(ctx, &inserted, smt, args...); err != nil {
+		return nil, err
+	}
+	for _, c := range inserted {
+		ids[c.Code] = c.ID
+	}
+	return ids, nil
+}
+
+func (t *postgresTx) CreateSnapshot(ctx context.Context, source string) (int64, error) {
+	return returningID(ctx, t.tx, t.insertSnapshot(source))
+}
+
+func (t *postgresTx) UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error) {
+	if len(servers) == 0 {
+		return 0, 0, nil
+	}
+	// xmax of a row is 0 unless the statement updated an existing row
+	smt, args, err := t.insertServers(servers).
+		Suffix("ON CONFLICT (source, ip, host_name) DO UPDATE SET\n\t\t\t" + upsertServerAssignments("excluded.%s") +
+			"\n\t\tRETURNING xmax = 0").
+		ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	var isNew []bool
+	if err := t.tx.SelectContext(ctx, &isNew, smt, args...); err != nil {
+		return 0, 0, err
+	}
+	var inserted, updated int64
+	for _, n := range isNew {
+		if n {
+			inserted++
+		} else {
+			updated++
+		}
+	}
+	return inserted, updated, nil
+}
+
+// returningID runs insert with q and returns the id of the inserted row
+func returningID(ctx context.Context, q sqlx.QueryerContext, insert sq.InsertBuilder) (int64, error) {
+	smt, args, err := insert.Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var id int64
+	if err := sqlx.GetContext(ctx, q, &id, smt, args...); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
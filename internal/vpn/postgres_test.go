@@ -0,0 +1,56 @@
+package vpn
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+)
+
+// kEnvTestPostgresDSN is the environment variable holding the DSN of a disposable PostgreSQL database,
+// the PostgreSQL tests are skipped when it is empty
+const kEnvTestPostgresDSN = "TEST_POSTGRES_DSN"
+
+// newTestPostgresRepository creates a repository backed by a freshly migrated PostgreSQL database
+func newTestPostgresRepository(t *testing.T) Repository {
+	dsn := os.Getenv(kEnvTestPostgresDSN)
+	if len(dsn) == 0 {
+		t.Skip(kEnvTestPostgresDSN + " is not set")
+	}
+	db, err := sqlx.Connect(postgresDriverName, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := newMigrate(db.DB, postgresDriverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Down() })
+	return NewRepository(db)
+}
+
+func Test_postgresRepository_crawl(t *testing.T) {
+	testRepositoryCrawl(t, newTestPostgresRepository(t))
+}
+
+func Test_postgresRepository_FindVPNServers(t *testing.T) {
+	testRepositoryFindVPNServers(t, newTestPostgresRepository(t))
+}
+
+func Test_postgresRepository_AcquireLease(t *testing.T) {
+	testRepositoryAcquireLease(t, newTestPostgresRepository(t))
+}
+
+func Test_postgresRepository_FindServerRulesVersion(t *testing.T) {
+	testRepositoryFindServerRulesVersion(t, newTestPostgresRepository(t))
+}
+
+func Test_postgresRepository_UpdateReachability(t *testing.T) {
+	testRepositoryUpdateReachability(t, newTestPostgresRepository(t))
+}
@@ -0,0 +1,104 @@
+package vpn
+
+import (
+	"context"
+	"net"
+	"squirrel-srv/pkg/logger"
+	"squirrel-srv/pkg/probe"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Reachability is the outcome of probing a VPN server from our own infrastructure
+type Reachability struct {
+	ServerID  int32
+	Reachable bool
+	Latency   time.Duration
+}
+
+// Prober probes the OpenVPN port of every VPN server with a bounded number of concurrent probes
+type Prober struct {
+	repo        Repository
+	concurrency int
+	timeout     time.Duration
+}
+
+// ProbeAll probes every VPN server that is not soft deleted and records the results
+func (p *Prober) ProbeAll(ctx context.Context) ([]Reachability, error) {
+	servers, err := p.repo.FindProbeTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Reachability, len(servers))
+	sem := make(chan struct{}, p.concurrency)
+	var wg sync.WaitGroup
+	for i, srv := range servers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// servers left unprobed must not be recorded as unreachable
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, srv *VPNServer) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = p.probe(ctx, srv)
+		}(i, srv)
+	}
+	wg.Wait()
+	// probes that failed because the run was canceled did not reach the servers
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := p.repo.UpdateReachability(ctx, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (p *Prober) probe(ctx context.Context, srv *VPNServer) Reachability {
+	addr := net.JoinHostPort(srv.IP, strconv.Itoa(int(srv.Port)))
+	var latency time.Duration
+	var err error
+	if srv.Protocol == "udp" {
+		latency, err = probe.OpenVPNUDP(ctx, addr, p.timeout)
+	} else {
+		latency, err = probe.TCP(ctx, addr, p.timeout)
+	}
+	if err != nil {
+		logger.Log.Debug("probe " + srv.Protocol + " " + addr + " error: " + err.Error())
+	}
+	return Reachability{
+		ServerID:  srv.ID,
+		Reachable: err == nil,
+		Latency:   latency,
+	}
+}
+
+// filterReachable drops the servers that failed at least maxFailures consecutive probes
+func filterReachable(servers []*VPNServer, maxFailures int32) []*VPNServer {
+	reachable := servers[:0]
+	for _, srv := range servers {
+		if srv.ProbeFailures < maxFailures {
+			reachable = append(reachable, srv)
+		}
+	}
+	return reachable
+}
+
+// NewProber creates a prober running at most concurrency probes at a time
+func NewProber(repo Repository, concurrency int, timeout time.Duration) *Prober {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Prober{
+		repo:        repo,
+		concurrency: concurrency,
+		timeout:     timeout,
+	}
+}
@@ -0,0 +1,161 @@
+package vpn
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// probeRepository serves probe targets and records the saved probe results
+type probeRepository struct {
+	Repository
+	targets []*VPNServer
+	results []Reachability
+	updated bool
+}
+
+func (r *probeRepository) FindProbeTargets(context.Context) ([]*VPNServer, error) {
+	return r.targets, nil
+}
+
+func (r *probeRepository) UpdateReachability(_ context.Context, results []Reachability) error {
+	r.results = results
+	r.updated = true
+	return nil
+}
+
+// localPort returns the port of a local address
+func localPort(t *testing.T, addr net.Addr) int32 {
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := strconv.Atoi(port)
+	return int32(p)
+}
+
+func Test_Prober_ProbeAll(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.Close()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = closed.Close()
+	// openvpn answers a client hard reset with a server hard reset, silent does not answer
+	openvpn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer openvpn.Close()
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			_, addr, err := openvpn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = openvpn.WriteTo([]byte{8 << 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, addr)
+		}
+	}()
+	silent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+
+	targets := []*VPNServer{
+		{ID: 1, IP: "127.0.0.1", Protocol: "tcp", Port: localPort(t, tcp.Addr())},
+		{ID: 2, IP: "127.0.0.1", Protocol: "tcp", Port: localPort(t, closed.Addr())},
+		{ID: 3, IP: "127.0.0.1", Protocol: "udp", Port: localPort(t, openvpn.LocalAddr())},
+		{ID: 4, IP: "127.0.0.1", Protocol: "udp", Port: localPort(t, silent.LocalAddr())},
+	}
+	wantReachable := []bool{true, false, true, false}
+	tests := []struct {
+		name        string
+		concurrency int
+	}{
+		{"Sequential probes should be mapped to their server", 1},
+		{"Concurrent probes should be mapped to their server", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &probeRepository{targets: targets}
+			results, err := NewProber(repo, tt.concurrency, 200*time.Millisecond).ProbeAll(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != len(targets) || len(repo.results) != len(targets) {
+				t.Fatalf("ProbeAll() = %d results, %d saved, want %d", len(results), len(repo.results), len(targets))
+			}
+			for i, r := range results {
+				if r.ServerID != targets[i].ID || r.Reachable != wantReachable[i] {
+					t.Errorf("ProbeAll() result %d = %+v, want server %d reachable %v", i, r, targets[i].ID, wantReachable[i])
+				}
+				if r != repo.results[i] {
+					t.Errorf("ProbeAll() saved result %d = %+v, want %+v", i, repo.results[i], r)
+				}
+			}
+		})
+	}
+
+	t.Run("Canceled probes should not be saved", func(t *testing.T) {
+		repo := &probeRepository{targets: targets}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := NewProber(repo, 1, 200*time.Millisecond).ProbeAll(ctx); err != context.Canceled {
+			t.Errorf("ProbeAll() error = %v, want %v", err, context.Canceled)
+		}
+		if repo.updated {
+			t.Error("ProbeAll() saved the results of canceled probes")
+		}
+	})
+}
+
+func Test_filterReachable(t *testing.T) {
+	servers := func(failures ...int32) []*VPNServer {
+		var res []*VPNServer
+		for i, f := range failures {
+			res = append(res, &VPNServer{ID: int32(i), ProbeFailures: f})
+		}
+		return res
+	}
+	tests := []struct {
+		name        string
+		servers     []*VPNServer
+		maxFailures int32
+		want        int
+	}{
+		{"Servers below the maximum should be kept", servers(0, 1, 2), 3, 3},
+		{"Servers at the maximum should be dropped", servers(0, 3, 2, 4), 3, 2},
+		{"Single failure maximum should keep only servers without failure", servers(0, 1, 0), 1, 2},
+		{"No server should be no server", nil, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterReachable(tt.servers, tt.maxFailures)
+			if len(got) != tt.want {
+				t.Fatalf("filterReachable() = %d servers, want %d", len(got), tt.want)
+			}
+			for _, srv := range got {
+				if srv.ProbeFailures >= tt.maxFailures {
+					t.Errorf("filterReachable() kept server %d with %d failures", srv.ID, srv.ProbeFailures)
+				}
+			}
+		})
+	}
+}
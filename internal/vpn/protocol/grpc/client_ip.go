@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"squirrel-srv/pkg/clientip"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// resolveClientIP returns the IP address of the caller. The address forwarded by the HTTP gateway is
+// trusted only when the peer is on the loopback interface, that is when the caller is the gateway itself.
+func resolveClientIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	ip := clientip.FromAddr(p.Addr.String())
+	if ip == nil || !ip.IsLoopback() {
+		return ip
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(clientip.MetadataKey) {
+			if forwarded := net.ParseIP(v); forwarded != nil {
+				return forwarded
+			}
+		}
+	}
+	return ip
+}
+
+// clientIPUnaryInterceptor adds the IP address of the caller to the context of unary calls
+func clientIPUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(clientip.NewContext(ctx, resolveClientIP(ctx)), req)
+}
+
+// clientIPStreamInterceptor adds the IP address of the caller to the context of streams
+func clientIPStreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = clientip.NewContext(ss.Context(), resolveClientIP(ss.Context()))
+	return handler(srv, wrapped)
+}
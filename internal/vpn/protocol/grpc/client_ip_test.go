@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"squirrel-srv/pkg/clientip"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// serverStream is a server stream that only carries a context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func Test_clientIPInterceptors(t *testing.T) {
+	withPeer := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 5000}})
+	}
+	forwarded := func(ctx context.Context, ips ...string) context.Context {
+		md := metadata.MD{}
+		for _, ip := range ips {
+			md.Append(clientip.MetadataKey, ip)
+		}
+		return metadata.NewIncomingContext(ctx, md)
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"Direct caller should be the peer", withPeer("203.0.113.7"), "203.0.113.7"},
+		{"Forwarded address of a remote peer should be ignored", forwarded(withPeer("203.0.113.7"), "198.51.100.1"), "203.0.113.7"},
+		{"Forwarded address of the gateway should be the caller", forwarded(withPeer("127.0.0.1"), "198.51.100.1"), "198.51.100.1"},
+		{"Invalid forwarded address should be skipped", forwarded(withPeer("::1"), "unknown", "198.51.100.1"), "198.51.100.1"},
+		{"Gateway without forwarded address should be the caller", withPeer("127.0.0.1"), "127.0.0.1"},
+		{"No peer should be no address", forwarded(context.Background(), "198.51.100.1"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := net.ParseIP(tt.want)
+
+			var got net.IP
+			_, err := clientIPUnaryInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = clientip.FromContext(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("clientIPUnaryInterceptor() client IP = %v, want %v", got, want)
+			}
+
+			got = nil
+			err = clientIPStreamInterceptor(nil, &serverStream{ctx: tt.ctx}, &grpc.StreamServerInfo{}, func(_ interface{}, ss grpc.ServerStream) error {
+				got = clientip.FromContext(ss.Context())
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("clientIPStreamInterceptor() client IP = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -54,6 +54,7 @@ func RunServer(ctx context.Context, v1API v1.ServiceServer, port string, creds c
 	opts = append(opts, grpc_middleware.WithUnaryServerChain(
 		grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 		grpc_zap.UnaryServerInterceptor(logger.Log, o...),
+		clientIPUnaryInterceptor,
 		grpc_auth.UnaryServerInterceptor(auth.VerifyClientKey),
 	))
 
@@ -61,6 +62,7 @@ func RunServer(ctx context.Context, v1API v1.ServiceServer, port string, creds c
 	opts = append(opts, grpc_middleware.WithStreamServerChain(
 		grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 		grpc_zap.StreamServerInterceptor(logger.Log, o...),
+		clientIPStreamInterceptor,
 		grpc_auth.StreamServerInterceptor(auth.VerifyClientKey),
 	))
 
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"squirrel-srv/pkg/clientip"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientIPMetadata forwards the IP address of the HTTP client to the gRPC server,
+// the gRPC server would otherwise only see the gateway as its peer.
+// The forwarding headers are only read from the trusted proxies.
+func ClientIPMetadata(trusted clientip.Proxies) func(context.Context, *http.Request) metadata.MD {
+	return func(_ context.Context, r *http.Request) metadata.MD {
+		ip := clientip.FromRequest(r, trusted)
+		if ip == nil {
+			return nil
+		}
+		return metadata.Pairs(clientip.MetadataKey, ip.String())
+	}
+}
@@ -0,0 +1,59 @@
+package restful
+
+import (
+	"mime"
+	"net/http"
+	"regexp"
+	"squirrel-srv/pkg/api/v1"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// profilePath matches /v1/servers/{id}/profile.ovpn
+var profilePath = regexp.MustCompile(`^/v1/servers/([^/]+)/profile\.ovpn$`)
+
+// serveProfile serves GetOpenVPNProfile as a file download instead of the JSON the generated
+// gateway handler would write, every other request is passed to next
+func serveProfile(mux *runtime.ServeMux, client v1.ServiceClient, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match := profilePath.FindStringSubmatch(r.URL.Path)
+		if match == nil || r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		id, err := strconv.ParseInt(match[1], 10, 32)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "invalid server id '%s'", match[1]))
+			return
+		}
+		query := r.URL.Query()
+		res, err := client.GetOpenVPNProfile(ctx, &v1.GetOpenVPNProfileRequest{
+			Api:        query.Get("api"),
+			Id:         int32(id),
+			Directives: query["directives"],
+			Dns:        query["dns"],
+		})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", res.ContentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": res.FileName,
+		}))
+		w.Header().Set("Content-Length", strconv.Itoa(len(res.Content)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(res.Content)
+	})
+}
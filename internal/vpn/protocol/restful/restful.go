@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"squirrel-srv/internal/vpn/protocol/restful/middleware"
 	"squirrel-srv/pkg/api/v1"
+	"squirrel-srv/pkg/clientip"
 	"squirrel-srv/pkg/logger"
 	"time"
 )
@@ -26,13 +27,13 @@ type errorBody struct {
 }
 
 // RunServer runs HTTP/REST gateway
-func RunServer(ctx context.Context, grpcPort, httpPort string, creds credentials.TransportCredentials) error {
+func RunServer(ctx context.Context, grpcPort, httpPort string, creds credentials.TransportCredentials, trusted clientip.Proxies) error {
 	runtime.HTTPError = customHTTPError
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(runtime.WithMetadata(middleware.ClientIPMetadata(trusted)))
 	var opts []grpc.DialOption
 	if creds != nil {
 		opts = append(opts, grpc.WithTransportCredentials(creds))
@@ -40,7 +41,16 @@ func RunServer(ctx context.Context, grpcPort, httpPort string, creds credentials
 		opts = append(opts, grpc.WithInsecure())
 	}
 
-	if err := v1.RegisterServiceHandlerFromEndpoint(ctx, mux, "127.0.0.1:"+grpcPort, opts); err != nil {
+	conn, err := grpc.DialContext(ctx, "127.0.0.1:"+grpcPort, opts...)
+	if err != nil {
+		logger.Log.Fatal("failed to start HTTP gateway", zap.String("reason", err.Error()))
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	if err := v1.RegisterServiceHandler(ctx, mux, conn); err != nil {
 		logger.Log.Fatal("failed to start HTTP gateway", zap.String("reason", err.Error()))
 	}
 
@@ -48,7 +58,7 @@ func RunServer(ctx context.Context, grpcPort, httpPort string, creds credentials
 		Addr: ":" + httpPort,
 		// Add handler with middlware
 		Handler: middleware.AddRequestID(
-			middleware.AddLogger(logger.Log, mux)),
+			middleware.AddLogger(logger.Log, serveProfile(mux, v1.NewServiceClient(conn), mux))),
 	}
 
 	// graceful shutdown
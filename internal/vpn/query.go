@@ -0,0 +1,222 @@
+package vpn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	// sortByPing orders VPN server lists by ping
+	sortByPing = "ping"
+	// sortByScore orders VPN server lists by the score of the source
+	sortByScore = "score"
+	// sortBySessions orders VPN server lists by number of VPN sessions
+	sortBySessions = "sessions"
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+
+	// defaultPageSize is number of VPN servers listed when the request has no page size
+	defaultPageSize = 50
+	// maxPageSize is the highest number of VPN servers listed in a page
+	maxPageSize = 200
+)
+
+var (
+	// sortColumns maps the sort keys of VPN server lists to their column
+	sortColumns = map[string]string{
+		sortBySpeed:    "vpn_servers.speed",
+		sortByPing:     "vpn_servers.ping",
+		sortByScore:    "vpn_servers.score",
+		sortBySessions: "vpn_servers.num_vpn_sessions",
+	}
+
+	ErrInvalidPageToken = errors.New("page token is invalid or does not match the sort order")
+)
+
+// VPNServerQuery selects VPN servers, zero fields do not filter
+type VPNServerQuery struct {
+	CountryCode string
+	MinSpeed    int64
+	MaxPing     int32
+	MaxSessions int32
+	Protocol    string
+	Operator    string
+	// MaxProbeFailures leaves out the servers that failed at least as many consecutive probes
+	MaxProbeFailures int32
+	// ExcludeIDs leaves out servers by id e.g. the ones denied by server rules
+	ExcludeIDs []int32
+
+	// SortBy is one of the sortColumns keys, ties are broken by id in the same order
+	SortBy string
+	Desc   bool
+	// After is the position of the last server of the previous page
+	After *VPNServerCursor
+	// Limit is the largest number of servers found, 0 finds every server
+	Limit uint64
+}
+
+// VPNServerCursor is a position in a sorted VPN server list
+type VPNServerCursor struct {
+	Value int64 `json:"v"`
+	ID    int32 `json:"i"`
+}
+
+// pageToken is the content of the page token of VPN server lists
+type pageToken struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	VPNServerCursor
+}
+
+// defaultOrder returns the order of a sort key listing the best servers first
+func defaultOrder(sortBy string) string {
+	if sortBy == sortByPing || sortBy == sortBySessions {
+		return orderAsc
+	}
+	return orderDesc
+}
+
+// order returns the order of q
+func (q VPNServerQuery) order() string {
+	if q.Desc {
+		return orderDesc
+	}
+	return orderAsc
+}
+
+// sortValue returns the value of srv the sort key orders by
+func sortValue(srv *VPNServer, sortBy string) int64 {
+	switch sortBy {
+	case sortByPing:
+		return int64(srv.Ping)
+	case sortByScore:
+		return int64(srv.Score)
+	case sortBySessions:
+		return int64(srv.NumVPNSessions)
+	}
+	return srv.Speed
+}
+
+// encodePageToken returns the token of the page following srv
+func encodePageToken(sortBy, order string, srv *VPNServer) string {
+	raw, _ := json.Marshal(pageToken{
+		SortBy:          sortBy,
+		Order:           order,
+		VPNServerCursor: VPNServerCursor{Value: sortValue(srv, sortBy), ID: srv.ID},
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken returns the cursor of a page token, the token must have been issued for the same sort order
+func decodePageToken(token, sortBy, order string) (*VPNServerCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	t := pageToken{}
+	if err := json.Unmarshal(raw, &t); err != nil || t.SortBy != sortBy || t.Order != order {
+		return nil, ErrInvalidPageToken
+	}
+	return &t.VPNServerCursor, nil
+}
+
+// filters returns the conditions of q on the vpn_servers table, servers of a country are selected by countryID
+func (q VPNServerQuery) filters(countryID int32) sq.And {
+	where := sq.And{sq.Eq{"vpn_servers.deleted_at": nil}}
+	if len(q.CountryCode) > 0 {
+		where = append(where, sq.Eq{"vpn_servers.country_id": countryID})
+	}
+	if q.MinSpeed > 0 {
+		where = append(where, sq.GtOrEq{"vpn_servers.speed": q.MinSpeed})
+	}
+	if q.MaxPing > 0 {
+		where = append(where, sq.LtOrEq{"vpn_servers.ping": q.MaxPing})
+	}
+	if q.MaxSessions > 0 {
+		where = append(where, sq.LtOrEq{"vpn_servers.num_vpn_sessions": q.MaxSessions})
+	}
+	if len(q.Protocol) > 0 {
+		where = append(where, sq.Eq{"vpn_servers.protocol": q.Protocol})
+	}
+	if len(q.Operator) > 0 {
+		where = append(where, sq.Eq{"vpn_servers.operator": q.Operator})
+	}
+	if q.MaxProbeFailures > 0 {
+		where = append(where, sq.Lt{"vpn_servers.probe_failures": q.MaxProbeFailures})
+	}
+	if len(q.ExcludeIDs) > 0 {
+		where = append(where, sq.NotEq{"vpn_servers.id": q.ExcludeIDs})
+	}
+	return where
+}
+
+// page restricts b to the page of q
+func (q VPNServerQuery) page(b sq.SelectBuilder) sq.SelectBuilder {
+	column := sortColumns[q.SortBy]
+	order := " asc"
+	if q.Desc {
+		order = " desc"
+	}
+	if q.After != nil {
+		// keyset pagination, the page starts right after the cursor in the (column, id) order
+		var past sq.Sqlizer = sq.Or{
+			sq.Gt{column: q.After.Value},
+			sq.And{sq.Eq{column: q.After.Value}, sq.Gt{"vpn_servers.id": q.After.ID}},
+		}
+		if q.Desc {
+			past = sq.Or{
+				sq.Lt{column: q.After.Value},
+				sq.And{sq.Eq{column: q.After.Value}, sq.Lt{"vpn_servers.id": q.After.ID}},
+			}
+		}
+		b = b.Where(past)
+	}
+	b = b.OrderBy(column+order, "vpn_servers.id"+order)
+	if q.Limit > 0 {
+		b = b.Limit(q.Limit)
+	}
+	return b
+}
+
+// match reports whether srv passes the filters of q, srv must hold the code of its country
+func (q VPNServerQuery) match(srv *VPNServer) bool {
+	return srv.DeletedAt == nil &&
+		(len(q.CountryCode) == 0 || srv.Country.Code == q.CountryCode) &&
+		(q.MinSpeed <= 0 || srv.Speed >= q.MinSpeed) &&
+		(q.MaxPing <= 0 || srv.Ping <= q.MaxPing) &&
+		(q.MaxSessions <= 0 || srv.NumVPNSessions <= q.MaxSessions) &&
+		(len(q.Protocol) == 0 || srv.Protocol == q.Protocol) &&
+		(len(q.Operator) == 0 || srv.Operator == q.Operator) &&
+		(q.MaxProbeFailures <= 0 || srv.ProbeFailures < q.MaxProbeFailures) &&
+		!q.excludes(srv.ID)
+}
+
+// excludes reports whether id is one of the ids q leaves out
+func (q VPNServerQuery) excludes(id int32) bool {
+	for _, excluded := range q.ExcludeIDs {
+		if excluded == id {
+			return true
+		}
+	}
+	return false
+}
+
+// before reports whether a comes before b in the order of q
+func (q VPNServerQuery) before(a, b VPNServerCursor) bool {
+	if a.Value != b.Value {
+		return (a.Value < b.Value) != q.Desc
+	}
+	if a.ID != b.ID {
+		return (a.ID < b.ID) != q.Desc
+	}
+	return false
+}
+
+// cursor returns the position of srv in the order of q
+func (q VPNServerQuery) cursor(srv *VPNServer) VPNServerCursor {
+	return VPNServerCursor{Value: sortValue(srv, q.SortBy), ID: srv.ID}
+}
@@ -0,0 +1,34 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_decodePageToken(t *testing.T) {
+	token := encodePageToken(sortByPing, orderAsc, &VPNServer{ID: 7, Ping: 12})
+	tests := []struct {
+		name    string
+		token   string
+		sortBy  string
+		order   string
+		want    *VPNServerCursor
+		wantErr bool
+	}{
+		{"Token should decode to the position of the server", token, sortByPing, orderAsc, &VPNServerCursor{Value: 12, ID: 7}, false},
+		{"Token of another sort key should be refused", token, sortBySpeed, orderAsc, nil, true},
+		{"Token of another order should be refused", token, sortByPing, orderDesc, nil, true},
+		{"Malformed token should be refused", "not a token", sortByPing, orderAsc, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(tt.token, tt.sortBy, tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodePageToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodePageToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package vpn
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sortBySpeed is the default order of VPN server lists, fastest first
+	sortBySpeed = "speed"
+	// sortByRank orders VPN server lists by the ranker score, best first
+	sortByRank = "rank"
+
+	// defaultRecommendedLimit is number of recommended servers returned when the request has no limit
+	defaultRecommendedLimit = 10
+	// maxRecommendedLimit is the highest number of recommended servers returned
+	maxRecommendedLimit = 100
+
+	// defaultRankWeights are the weights used when none are configured
+	defaultRankWeights = "speed=0.35,ping=0.25,sessions=0.15,uptime=0.1,reachability=0.15"
+)
+
+// RankWeights are the weights of the factors of the ranker score, they are normalized to sum 1
+type RankWeights struct {
+	Speed        float64
+	Ping         float64
+	Sessions     float64
+	Uptime       float64
+	Reachability float64
+}
+
+// ParseRankWeights parses comma separated factor=weight pairs e.g. speed=0.5,ping=0.5.
+// Factors left out weigh 0.
+func ParseRankWeights(value string) (RankWeights, error) {
+	var w RankWeights
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return w, fmt.Errorf("invalid rank weight '%s'", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil || weight < 0 || math.IsInf(weight, 0) {
+			return w, fmt.Errorf("invalid rank weight '%s'", pair)
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "speed":
+			w.Speed = weight
+		case "ping":
+			w.Ping = weight
+		case "sessions":
+			w.Sessions = weight
+		case "uptime":
+			w.Uptime = weight
+		case "reachability":
+			w.Reachability = weight
+		default:
+			return w, fmt.Errorf("unknown rank factor '%s'", kv[0])
+		}
+	}
+	if w.sum() == 0 {
+		return w, fmt.Errorf("rank weights must not all be 0")
+	}
+	return w, nil
+}
+
+func (w RankWeights) sum() float64 {
+	return w.Speed + w.Ping + w.Sessions + w.Uptime + w.Reachability
+}
+
+// RankedServer is a VPN server with its ranker score between 0 and 1
+type RankedServer struct {
+	*VPNServer
+	Score float64
+}
+
+// Ranker scores VPN servers relative to each other, every factor is scaled to [0, 1]
+// between the worst and the best server of the list before being weighted
+type Ranker struct {
+	weights RankWeights
+}
+
+// Rank scores servers and returns them best first, servers with the same score keep their order
+func (r *Ranker) Rank(servers []*VPNServer) []*RankedServer {
+	speed := newScale()
+	ping := newScale()
+	sessions := newScale()
+	uptime := newScale()
+	for _, srv := range servers {
+		// speed, sessions and uptime have long tails, the log keeps a few outliers from flattening the rest
+		speed.add(math.Log1p(float64(srv.Speed)))
+		sessions.add(math.Log1p(float64(srv.NumVPNSessions)))
+		uptime.add(math.Log1p(float64(srv.Uptime)))
+		if srv.Ping > 0 {
+			ping.add(float64(srv.Ping))
+		}
+	}
+
+	total := r.weights.sum()
+	ranked := make([]*RankedServer, len(servers))
+	for i, srv := range servers {
+		// a ping of 0 means VPNGate could not measure it, it scores half
+		pingScore := 0.5
+		if srv.Ping > 0 {
+			pingScore = ping.inverse(float64(srv.Ping))
+		}
+		score := r.weights.Speed*speed.normalize(math.Log1p(float64(srv.Speed))) +
+			r.weights.Ping*pingScore +
+			r.weights.Sessions*sessions.inverse(math.Log1p(float64(srv.NumVPNSessions))) +
+			r.weights.Uptime*uptime.normalize(math.Log1p(float64(srv.Uptime))) +
+			r.weights.Reachability*reachabilityScore(srv)
+		ranked[i] = &RankedServer{VPNServer: srv, Score: score / total}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// reachabilityScore is 1 for reachable servers, 0 for unreachable ones and 0.5 for servers not probed yet
+func reachabilityScore(srv *VPNServer) float64 {
+	if srv.ProbedAt == nil {
+		return 0.5
+	}
+	if srv.Reachable {
+		return 1
+	}
+	return 0
+}
+
+// scale is the range of a factor over a list of servers
+type scale struct {
+	min, max float64
+}
+
+func newScale() *scale {
+	return &scale{min: math.Inf(1), max: math.Inf(-1)}
+}
+
+func (s *scale) add(v float64) {
+	s.min = math.Min(s.min, v)
+	s.max = math.Max(s.max, v)
+}
+
+// normalize maps v to [0, 1], it returns 1 when every server has the same value
+func (s *scale) normalize(v float64) float64 {
+	if s.max <= s.min {
+		return 1
+	}
+	return (v - s.min) / (s.max - s.min)
+}
+
+// inverse maps v to [0, 1] where the lowest value scores 1, it returns 1 when every server has the same value
+func (s *scale) inverse(v float64) float64 {
+	if s.max <= s.min {
+		return 1
+	}
+	return (s.max - v) / (s.max - s.min)
+}
+
+// NewRanker creates a ranker with weights
+func NewRanker(weights RankWeights) *Ranker {
+	return &Ranker{
+		weights: weights,
+	}
+}
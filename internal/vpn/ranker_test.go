@@ -0,0 +1,127 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRankWeights(t *testing.T) {
+	type args struct {
+		value string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    RankWeights
+		wantErr bool
+	}{
+		{
+			"Unknown factor should be error",
+			args{
+				"speed=1,load=1",
+			},
+			RankWeights{},
+			true,
+		},
+		{
+			"Negative weight should be error",
+			args{
+				"speed=-1",
+			},
+			RankWeights{},
+			true,
+		},
+		{
+			"All weights 0 should be error",
+			args{
+				"speed=0,ping=0",
+			},
+			RankWeights{},
+			true,
+		},
+		{
+			"Default weights",
+			args{
+				defaultRankWeights,
+			},
+			RankWeights{Speed: 0.35, Ping: 0.25, Sessions: 0.15, Uptime: 0.1, Reachability: 0.15},
+			false,
+		},
+		{
+			"Factors left out weigh 0",
+			args{
+				" ping = 2 ,",
+			},
+			RankWeights{Ping: 2},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRankWeights(tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRankWeights() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRankWeights() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRanker_Rank(t *testing.T) {
+	probedAt := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	fast := &VPNServer{ID: 1, Speed: 100000000, Ping: 50, NumVPNSessions: 200, Uptime: 1000}
+	idle := &VPNServer{ID: 2, Speed: 10000000, Ping: 10, NumVPNSessions: 0, Uptime: 100000}
+	unreachable := &VPNServer{ID: 3, Speed: 100000000, Ping: 10, NumVPNSessions: 0, Uptime: 100000, ProbedAt: &probedAt}
+	type args struct {
+		weights RankWeights
+		servers []*VPNServer
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int32
+	}{
+		{
+			"Speed only",
+			args{
+				RankWeights{Speed: 1},
+				[]*VPNServer{idle, fast},
+			},
+			[]int32{1, 2},
+		},
+		{
+			"Ping and sessions",
+			args{
+				RankWeights{Speed: 1, Ping: 1, Sessions: 1},
+				[]*VPNServer{fast, idle},
+			},
+			[]int32{2, 1},
+		},
+		{
+			"Unreachable server goes last",
+			args{
+				RankWeights{Speed: 1, Reachability: 10},
+				[]*VPNServer{unreachable, idle, fast},
+			},
+			[]int32{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int32
+			for _, r := range NewRanker(tt.args.weights).Rank(tt.args.servers) {
+				if r.Score < 0 || r.Score > 1 {
+					t.Errorf("Rank() score of %d = %v, want between 0 and 1", r.ID, r.Score)
+				}
+				got = append(got, r.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package vpn
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"squirrel-srv/pkg/logger"
+)
+
+// replaySource is a source serving an archived feed, it parses the feed like the source that fetched it
+type replaySource struct {
+	Source
+	feed *Feed
+}
+
+func (s *replaySource) Fetch(_ context.Context) (*Feed, error) {
+	return s.feed, nil
+}
+
+// RunReplay re-parses an archived feed and prints what the crawler makes of it,
+// with -import the feed goes through the whole crawl pipeline into the database
+func RunReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	doImport := fs.Bool("import", false, "Import the feed into the database through the crawl pipeline")
+	sourceName := fs.String("source", "", "Source parsing the feed, defaults to the source recorded in the archive")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: squirrel replay [flags] <archived feed .gz>")
+		fs.PrintDefaults()
+	}
+	cfg, err := loadConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("replay needs exactly one archived feed")
+	}
+
+	if err := logger.Init(cfg.LogLevel, cfg.LogTimeFormat); err != nil {
+		return fmt.Errorf("failed to initialize logger: %v", err)
+	}
+
+	feed, meta, err := OpenArchivedFeed(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to open archived feed: %v", err)
+	}
+	name := *sourceName
+	if len(name) == 0 {
+		name = meta.Source
+	}
+	if len(name) == 0 {
+		return errors.New("archived feed has no source, set it with -source")
+	}
+	src, err := newSource(name, cfg)
+	if err != nil {
+		return err
+	}
+	replay := &replaySource{Source: src, feed: feed}
+
+	if !*doImport {
+		parsed, err := replay.Parse(feed.Content)
+		if err != nil {
+			return err
+		}
+		servers, rejected := applyOpenVPNConfigs(parsed.Servers)
+		rejected = append(parsed.Rejected, rejected...)
+		fmt.Printf("source: %s\nurl: %s\nfetched at: %s\n", name, meta.URL, meta.FetchedAt)
+		fmt.Printf("rows: %d, parsed: %d, rejected: %d, truncated: %t\n",
+			parsed.Rows, len(servers), len(rejected), parsed.Truncated)
+		for _, r := range rejected {
+			fmt.Printf("  line %d %s %s: %s\n", r.Line, r.HostName, r.IP, r.Reason)
+		}
+		return nil
+	}
+
+	repo, closeRepo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+	geo, err := openGeoIP(cfg)
+	if err != nil {
+		return err
+	}
+	if geo != nil {
+		defer geo.Close()
+	}
+
+	// the replayed feed is already archived
+	crawler := NewCrawler(repo, []Source{replay}, geo, nil, cfg)
+	servers, err := crawler.Crawl(context.Background(), replay)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d servers from %s\n", len(servers), fs.Arg(0))
+	return nil
+}
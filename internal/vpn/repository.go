@@ -1,27 +1,92 @@
 package vpn
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 var (
 	ErrCountryNotFound       = errors.New("country was not found")
 	ErrVPNServerNotFound = errors.New("vpn server was not found")
+	ErrCrawlRunNotFound  = errors.New("crawl run was not found")
+	ErrServerRuleNotFound = errors.New("server rule was not found")
 )
 
 
 type Repository interface {
-	// Create country
-	CreateCountry(Country) (int64, error)
 	// FindCountryByCode finds a country by code
-	FindCountryByCode(string) (*Country, error)
+	FindCountryByCode(ctx context.Context, code string) (*Country, error)
 	// FindAppCountry
-	FindAllCountryHaveVPNServer() ([]*Country, error)
+	FindAllCountryHaveVPNServer(ctx context.Context) ([]*Country, error)
 
-	// Create VPNServer
-	Create(VPNServer) (int64, error)
 	// FindVPNServerByCountryCode
-	FindVPNServerByCountryCode(string) ([]*VPNServer, error)
+	FindVPNServerByCountryCode(ctx context.Context, code string) ([]*VPNServer, error)
 	// FindAllVPNServer
-	FindAllVPNServer() ([]*VPNServer, error)
-	// Truncate
-	Truncate() error
-}
\ No newline at end of file
+	FindAllVPNServer(ctx context.Context) ([]*VPNServer, error)
+	// FindVPNServers finds a page of the VPN servers matching query and counts every server matching its filters
+	FindVPNServers(ctx context.Context, query VPNServerQuery) ([]*VPNServer, int64, error)
+	// FindVPNServerByID finds a VPN server by id
+	FindVPNServerByID(ctx context.Context, id int32) (*VPNServer, error)
+	// FindProbeTargets finds id, IP, protocol and port of every VPN server that is not soft deleted
+	FindProbeTargets(ctx context.Context) ([]*VPNServer, error)
+	// CountVPNServers counts the VPN servers of a source that are not soft deleted
+	CountVPNServers(ctx context.Context, source string) (int64, error)
+	// UpdateReachability saves probe results, consecutive failures are counted per server
+	UpdateReachability(ctx context.Context, results []Reachability) error
+
+	// Transaction runs fn in a transaction, it is committed when fn returns nil and rolled back otherwise
+	Transaction(ctx context.Context, fn func(Tx) error) error
+	// PurgeSnapshots deletes the inactive snapshots of a source
+	PurgeSnapshots(ctx context.Context, source string) error
+
+	// CreateCrawlRun records the start of a crawl
+	CreateCrawlRun(ctx context.Context, run CrawlRun) (int64, error)
+	// UpdateCrawlRun saves the outcome of a crawl
+	UpdateCrawlRun(ctx context.Context, run CrawlRun) error
+	// FindCrawlRunByID finds a crawl run by id
+	FindCrawlRunByID(ctx context.Context, id int32) (*CrawlRun, error)
+	// FindCrawlRuns finds the latest crawl runs, optionally of a single source
+	FindCrawlRuns(ctx context.Context, source string, limit uint64) ([]*CrawlRun, error)
+
+	// FindServerMetrics finds the metric points of a VPN server crawled in [from, to) ordered by time
+	FindServerMetrics(ctx context.Context, serverID int32, from, to time.Time) ([]*ServerMetric, error)
+	// PruneServerMetrics deletes the metric points crawled before a time
+	PruneServerMetrics(ctx context.Context, before time.Time) (int64, error)
+
+	// CreateServerRule creates a server rule and returns its id
+	CreateServerRule(ctx context.Context, rule ServerRule) (int64, error)
+	// FindServerRuleByID finds a server rule by id
+	FindServerRuleByID(ctx context.Context, id int32) (*ServerRule, error)
+	// FindServerRules finds every server rule, expired ones included
+	FindServerRules(ctx context.Context) ([]*ServerRule, error)
+	// DeleteServerRule deletes a server rule
+	DeleteServerRule(ctx context.Context, id int32) error
+	// FindRuleTargets finds every VPN server with only the columns server rules match on
+	FindRuleTargets(ctx context.Context) ([]*VPNServer, error)
+	// FindServerRulesVersion returns the version of the server rules, it is a single cheap query
+	FindServerRulesVersion(ctx context.Context) (ServerRulesVersion, error)
+
+	// AcquireLease takes or renews the lease name for holder until ttl elapses.
+	// It reports whether holder owns the lease.
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// ReleaseLease gives up the lease name if it is owned by holder
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// Tx is the set of repository writes that run inside a transaction
+type Tx interface {
+	// ResolveCountries creates the countries whose code does not exist yet and maps every code to its id
+	ResolveCountries(ctx context.Context, countries []Country) (map[string]int32, error)
+	// CreateSnapshot creates an inactive snapshot for a source
+	CreateSnapshot(ctx context.Context, source string) (int64, error)
+	// UpsertBatch inserts VPN servers with a single statement, a server with the same source, IP and
+	// host name as an existing one updates it instead. It reports the number of inserted and updated servers.
+	UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error)
+	// DeleteMissing soft deletes the VPN servers of a source that are not part of a snapshot
+	DeleteMissing(ctx context.Context, source string, snapshotID int64) (int64, error)
+	// RecordMetrics records a metric point for every VPN server of a snapshot
+	RecordMetrics(ctx context.Context, snapshotID int64) (int64, error)
+	// ActivateSnapshot makes a snapshot the active one of its source
+	ActivateSnapshot(ctx context.Context, source string, id int64) error
+}
@@ -0,0 +1,362 @@
+package vpn
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// persistTestCrawl persists servers as a crawl of source the way the crawler does
+func persistTestCrawl(repo Repository, source string, servers []*VPNServer) (inserted, updated, removed int64, err error) {
+	err = repo.Transaction(context.Background(), func(tx Tx) error {
+		snapshotID, err := tx.CreateSnapshot(context.Background(), source)
+		if err != nil {
+			return err
+		}
+		var countries []Country
+		for _, srv := range servers {
+			countries = append(countries, srv.Country)
+		}
+		ids, err := tx.ResolveCountries(context.Background(), countries)
+		if err != nil {
+			return err
+		}
+		for _, srv := range servers {
+			srv.Source = source
+			srv.CountryID = ids[srv.Country.Code]
+			srv.SnapshotID = int32(snapshotID)
+		}
+		if inserted, updated, err = tx.UpsertBatch(context.Background(), servers); err != nil {
+			return err
+		}
+		if removed, err = tx.DeleteMissing(context.Background(), source, snapshotID); err != nil {
+			return err
+		}
+		if _, err := tx.RecordMetrics(context.Background(), snapshotID); err != nil {
+			return err
+		}
+		return tx.ActivateSnapshot(context.Background(), source, snapshotID)
+	})
+	return inserted, updated, removed, err
+}
+
+// testRepositoryCrawl checks consecutive crawls are persisted by repo like the MySQL repository does
+func testRepositoryCrawl(t *testing.T, repo Repository) {
+	japan := Country{Name: "Japan", Code: "JP"}
+	korea := Country{Name: "Korea Republic of", Code: "KR"}
+
+	inserted, updated, removed, err := persistTestCrawl(repo, "vpngate", []*VPNServer{
+		{HostName: "a", IP: "1.1.1.1", Speed: 10, Country: japan},
+		{HostName: "b", IP: "2.2.2.2", Speed: 20, Country: korea},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted != 2 || updated != 0 || removed != 0 {
+		t.Errorf("first crawl = %d inserted, %d updated, %d removed, want 2, 0, 0", inserted, updated, removed)
+	}
+
+	inserted, updated, removed, err = persistTestCrawl(repo, "vpngate", []*VPNServer{
+		{HostName: "b", IP: "2.2.2.2", Speed: 30, Country: korea},
+		{HostName: "c", IP: "3.3.3.3", Speed: 5, Country: japan},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted != 1 || updated != 1 || removed != 1 {
+		t.Errorf("second crawl = %d inserted, %d updated, %d removed, want 1, 1, 1", inserted, updated, removed)
+	}
+
+	servers, err := repo.FindAllVPNServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 2 || servers[0].HostName != "b" || servers[0].Speed != 30 || servers[0].Country.Code != "KR" {
+		t.Fatalf("FindAllVPNServer() = %+v, want b updated then c", servers)
+	}
+	count, err := repo.CountVPNServers(context.Background(), "vpngate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("CountVPNServers() = %d, want 2", count)
+	}
+	metrics, err := repo.FindServerMetrics(context.Background(), servers[0].ID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("FindServerMetrics() got %d points, want 2", len(metrics))
+	}
+
+	// a soft deleted server seen again is restored as an update of its row
+	inserted, updated, removed, err = persistTestCrawl(repo, "vpngate", []*VPNServer{
+		{HostName: "a", IP: "1.1.1.1", Speed: 15, Country: japan},
+		{HostName: "b", IP: "2.2.2.2", Speed: 30, Country: korea},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted != 0 || updated != 2 || removed != 1 {
+		t.Errorf("third crawl = %d inserted, %d updated, %d removed, want 0, 2, 1", inserted, updated, removed)
+	}
+	servers, err = repo.FindAllVPNServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hosts []string
+	for _, srv := range servers {
+		hosts = append(hosts, srv.HostName)
+	}
+	if !reflect.DeepEqual(hosts, []string{"b", "a"}) || servers[1].Speed != 15 {
+		t.Errorf("FindAllVPNServer() = %v, want b then a restored", hosts)
+	}
+}
+
+// testRepositoryAcquireLease checks the lease semantics of repo
+func testRepositoryAcquireLease(t *testing.T, repo Repository) {
+	tests := []struct {
+		name   string
+		holder string
+		ttl    time.Duration
+		want   bool
+	}{
+		{"Free lease should be acquired", "a", time.Hour, true},
+		{"Owned lease should be renewed", "a", -time.Second, true},
+		{"Expired lease should be taken over", "b", time.Hour, true},
+		{"Lease of another holder should not be acquired", "a", time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.AcquireLease(context.Background(), "scheduler", tt.holder, tt.ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("AcquireLease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testRepositoryFindServerRulesVersion checks the server rules version of repo changes with the rules
+func testRepositoryFindServerRulesVersion(t *testing.T, repo Repository) {
+	ctx := context.Background()
+	versions := make(map[ServerRulesVersion]string)
+	record := func(step string) {
+		version, err := repo.FindServerRulesVersion(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if previous, ok := versions[version]; ok {
+			t.Errorf("FindServerRulesVersion() after %s = %+v, the same as after %s", step, version, previous)
+		}
+		versions[version] = step
+	}
+	record("no rule")
+	first, err := repo.CreateServerRule(ctx, ServerRule{Action: ruleActionDeny, CountryCode: "JP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record("first rule")
+	second, err := repo.CreateServerRule(ctx, ServerRule{Action: ruleActionDeny, CountryCode: "KR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record("second rule")
+	if err := repo.DeleteServerRule(ctx, int32(second)); err != nil {
+		t.Fatal(err)
+	}
+	// the count and the highest id are back to the ones of the first rule alone
+	if _, err := repo.CreateServerRule(ctx, ServerRule{Action: ruleActionDeny, CountryCode: "US"}); err != nil {
+		t.Fatal(err)
+	}
+	record("replaced rule")
+	if err := repo.DeleteServerRule(ctx, int32(first)); err != nil {
+		t.Fatal(err)
+	}
+	record("deleted rule")
+}
+
+// testRepositoryFindVPNServers checks repo filters, sorts and paginates VPN servers
+func testRepositoryFindVPNServers(t *testing.T, repo Repository) {
+	japan := Country{Name: "Japan", Code: "JP"}
+	korea := Country{Name: "Korea Republic of", Code: "KR"}
+	_, _, _, err := persistTestCrawl(repo, "vpngate", []*VPNServer{
+		{HostName: "a", IP: "1.1.1.1", Speed: 30, Ping: 20, Protocol: "udp", Country: japan},
+		{HostName: "b", IP: "2.2.2.2", Speed: 10, Ping: 5, Protocol: "tcp", Country: korea},
+		{HostName: "c", IP: "3.3.3.3", Speed: 30, Ping: 10, Protocol: "tcp", Country: japan},
+		{HostName: "d", IP: "4.4.4.4", Speed: 20, Ping: 15, Protocol: "tcp", Country: japan},
+		{HostName: "e", IP: "5.5.5.5", Speed: 5, Ping: 1, Protocol: "udp", Country: korea},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		query     VPNServerQuery
+		want      []string
+		wantTotal int64
+	}{
+		{
+			name:      "Pages should follow the sort key then the id",
+			query:     VPNServerQuery{SortBy: sortBySpeed, Desc: true, Limit: 2},
+			want:      []string{"c", "a", "d", "b", "e"},
+			wantTotal: 5,
+		},
+		{
+			name:      "Ascending pages should follow the sort key",
+			query:     VPNServerQuery{SortBy: sortByPing, Limit: 3},
+			want:      []string{"e", "b", "c", "d", "a"},
+			wantTotal: 5,
+		},
+		{
+			name:      "Filters should all apply",
+			query:     VPNServerQuery{CountryCode: "JP", Protocol: "tcp", MaxPing: 12, SortBy: sortBySpeed, Desc: true},
+			want:      []string{"c"},
+			wantTotal: 1,
+		},
+		{
+			name:      "Minimum speed should be inclusive",
+			query:     VPNServerQuery{MinSpeed: 20, SortBy: sortBySpeed, Limit: 1},
+			want:      []string{"d", "a", "c"},
+			wantTotal: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			var got []string
+			for {
+				servers, total, err := repo.FindVPNServers(context.Background(), query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if total != tt.wantTotal {
+					t.Errorf("FindVPNServers() total = %d, want %d", total, tt.wantTotal)
+				}
+				for _, srv := range servers {
+					got = append(got, srv.HostName)
+				}
+				if query.Limit == 0 || uint64(len(servers)) < query.Limit || len(got) > len(tt.want) {
+					break
+				}
+				cursor := query.cursor(servers[len(servers)-1])
+				query.After = &cursor
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindVPNServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	targets, err := repo.FindRuleTargets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]int32)
+	for _, srv := range targets {
+		if srv.Country.Code != "JP" && srv.Country.Code != "KR" {
+			t.Errorf("FindRuleTargets() server %s in '%s', want its country code", srv.HostName, srv.Country.Code)
+		}
+		ids[srv.HostName] = srv.ID
+	}
+	if len(ids) != 5 {
+		t.Fatalf("FindRuleTargets() = %d servers, want 5", len(ids))
+	}
+	excluded := VPNServerQuery{SortBy: sortBySpeed, Desc: true, ExcludeIDs: []int32{ids["a"], ids["d"]}, Limit: 2}
+	servers, total, err := repo.FindVPNServers(context.Background(), excluded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(servers) != 2 || servers[0].HostName != "c" || servers[1].HostName != "b" {
+		t.Errorf("FindVPNServers() excluding a and d = %d servers of %d, want c and b of 3", len(servers), total)
+	}
+
+	if _, _, err := repo.FindVPNServers(context.Background(), VPNServerQuery{CountryCode: "FR", SortBy: sortBySpeed}); err != ErrCountryNotFound {
+		t.Errorf("FindVPNServers() error = %v, want %v", err, ErrCountryNotFound)
+	}
+}
+
+// testRepositoryUpdateReachability checks repo saves consecutive probe rounds and counts the failures per server
+func testRepositoryUpdateReachability(t *testing.T, repo Repository) {
+	japan := Country{Name: "Japan", Code: "JP"}
+	if _, _, _, err := persistTestCrawl(repo, "vpngate", []*VPNServer{
+		{HostName: "a", IP: "1.1.1.1", Country: japan},
+		{HostName: "b", IP: "2.2.2.2", Country: japan},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	targets, err := repo.FindRuleTargets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]int32)
+	for _, srv := range targets {
+		ids[srv.HostName] = srv.ID
+	}
+	type probe struct {
+		reachable bool
+		latency   time.Duration
+	}
+	type state struct {
+		reachable bool
+		latencyMs int32
+		failures  int32
+	}
+	// rounds run one after the other on the same servers
+	tests := []struct {
+		name string
+		// padding is a number of results of unknown servers saved before the results of the round
+		padding int
+		round   map[string]probe
+		want    map[string]state
+	}{
+		{
+			"First round should save every result",
+			0,
+			map[string]probe{"a": {true, 30 * time.Millisecond}, "b": {false, 0}},
+			map[string]state{"a": {true, 30, 0}, "b": {false, 0, 1}},
+		},
+		{
+			"Failures should add up across rounds",
+			0,
+			map[string]probe{"a": {false, 0}, "b": {false, 0}},
+			map[string]state{"a": {false, 0, 1}, "b": {false, 0, 2}},
+		},
+		{
+			"Results beyond a batch should be saved and reset the failures",
+			reachabilityBatchSize,
+			map[string]probe{"a": {false, 0}, "b": {true, 12 * time.Millisecond}},
+			map[string]state{"a": {false, 0, 2}, "b": {true, 12, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []Reachability
+			for i := 0; i < tt.padding; i++ {
+				results = append(results, Reachability{ServerID: int32(1000000 + i)})
+			}
+			for host, p := range tt.round {
+				results = append(results, Reachability{ServerID: ids[host], Reachable: p.reachable, Latency: p.latency})
+			}
+			if err := repo.UpdateReachability(context.Background(), results); err != nil {
+				t.Fatal(err)
+			}
+			for host, want := range tt.want {
+				srv, err := repo.FindVPNServerByID(context.Background(), ids[host])
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := state{srv.Reachable, srv.ProbeLatencyMs, srv.ProbeFailures}
+				if got != want {
+					t.Errorf("UpdateReachability() server %s = %+v, want %+v", host, got, want)
+				}
+				if srv.ProbedAt == nil {
+					t.Errorf("UpdateReachability() server %s has no probe time", host)
+				}
+			}
+		})
+	}
+}
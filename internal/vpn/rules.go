@@ -0,0 +1,154 @@
+package vpn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ruleActionAllow keeps the servers a rule matches even when a deny rule matches them too
+	ruleActionAllow = "allow"
+	// ruleActionDeny excludes the servers a rule matches
+	ruleActionDeny = "deny"
+)
+
+var (
+	ErrInvalidServerRule = errors.New("invalid server rule")
+)
+
+// validateServerRule normalizes the criteria of rule and checks they are valid.
+// A rule needs an action and at least one criterion.
+func validateServerRule(rule *ServerRule, now time.Time) error {
+	rule.Action = strings.ToLower(strings.TrimSpace(rule.Action))
+	rule.IPCIDR = strings.TrimSpace(rule.IPCIDR)
+	rule.HostNamePattern = strings.ToLower(strings.TrimSpace(rule.HostNamePattern))
+	rule.OperatorPattern = strings.ToLower(strings.TrimSpace(rule.OperatorPattern))
+	rule.CountryCode = strings.ToUpper(strings.TrimSpace(rule.CountryCode))
+
+	if rule.Action != ruleActionAllow && rule.Action != ruleActionDeny {
+		return fmt.Errorf("%w: unknown action '%s'", ErrInvalidServerRule, rule.Action)
+	}
+	if len(rule.IPCIDR) == 0 && len(rule.HostNamePattern) == 0 && len(rule.OperatorPattern) == 0 && len(rule.CountryCode) == 0 {
+		return fmt.Errorf("%w: rule has no criteria", ErrInvalidServerRule)
+	}
+	if len(rule.IPCIDR) > 0 && parseIPNet(rule.IPCIDR) == nil {
+		return fmt.Errorf("%w: '%s' is neither an IP address nor a CIDR", ErrInvalidServerRule, rule.IPCIDR)
+	}
+	for _, pattern := range []string{rule.HostNamePattern, rule.OperatorPattern} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: invalid pattern '%s'", ErrInvalidServerRule, pattern)
+		}
+	}
+	if len(rule.CountryCode) > 0 && !isCountryCode(rule.CountryCode) {
+		return fmt.Errorf("%w: invalid country code '%s'", ErrInvalidServerRule, rule.CountryCode)
+	}
+	if rule.ExpiresAt != nil && !rule.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expiry is in the past", ErrInvalidServerRule)
+	}
+	return nil
+}
+
+// parseIPNet parses a CIDR or a single IP address as a network of one address
+func parseIPNet(value string) *net.IPNet {
+	if _, ipNet, err := net.ParseCIDR(value); err == nil {
+		return ipNet
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// matchServerRule reports whether every criterion of rule matches srv
+func matchServerRule(rule *ServerRule, srv *VPNServer) bool {
+	if len(rule.IPCIDR) > 0 {
+		ipNet := parseIPNet(rule.IPCIDR)
+		ip := net.ParseIP(srv.IP)
+		if ipNet == nil || ip == nil || !ipNet.Contains(ip) {
+			return false
+		}
+	}
+	if len(rule.HostNamePattern) > 0 {
+		if ok, _ := path.Match(rule.HostNamePattern, strings.ToLower(srv.HostName)); !ok {
+			return false
+		}
+	}
+	if len(rule.OperatorPattern) > 0 {
+		if ok, _ := path.Match(rule.OperatorPattern, strings.ToLower(srv.Operator)); !ok {
+			return false
+		}
+	}
+	if len(rule.CountryCode) > 0 && !strings.EqualFold(rule.CountryCode, srv.Country.Code) {
+		return false
+	}
+	return true
+}
+
+// hasDenyRule reports whether a deny rule of rules is not expired
+func hasDenyRule(rules []*ServerRule, now time.Time) bool {
+	for _, rule := range rules {
+		if rule.Action != ruleActionAllow && (rule.ExpiresAt == nil || rule.ExpiresAt.After(now)) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyServerRules drops the servers matching a deny rule and no allow rule, expired rules are ignored.
+// Every dropped server is reported with the first deny rule matching it.
+func applyServerRules(servers []*VPNServer, rules []*ServerRule, now time.Time) ([]*VPNServer, []RejectedRow) {
+	var allow, deny []*ServerRule
+	for _, rule := range rules {
+		if rule.ExpiresAt != nil && !rule.ExpiresAt.After(now) {
+			continue
+		}
+		if rule.Action == ruleActionAllow {
+			allow = append(allow, rule)
+		} else {
+			deny = append(deny, rule)
+		}
+	}
+	if len(deny) == 0 {
+		return servers, nil
+	}
+
+	kept := make([]*VPNServer, 0, len(servers))
+	var denied []RejectedRow
+	for _, srv := range servers {
+		var denyRule *ServerRule
+		for _, rule := range deny {
+			if matchServerRule(rule, srv) {
+				denyRule = rule
+				break
+			}
+		}
+		if denyRule != nil {
+			allowed := false
+			for _, rule := range allow {
+				if matchServerRule(rule, srv) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				denied = append(denied, RejectedRow{
+					HostName: srv.HostName,
+					IP:       srv.IP,
+					Reason:   "denied by server rule " + strconv.Itoa(int(denyRule.ID)),
+				})
+				continue
+			}
+		}
+		kept = append(kept, srv)
+	}
+	return kept, denied
+}
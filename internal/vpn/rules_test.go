@@ -0,0 +1,172 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_validateServerRule(t *testing.T) {
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	type args struct {
+		rule ServerRule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    ServerRule
+		wantErr bool
+	}{
+		{
+			"Unknown action should be error",
+			args{
+				ServerRule{Action: "block", CountryCode: "JP"},
+			},
+			ServerRule{},
+			true,
+		},
+		{
+			"Rule without criteria should be error",
+			args{
+				ServerRule{Action: "deny", Comment: "everything"},
+			},
+			ServerRule{},
+			true,
+		},
+		{
+			"Invalid CIDR should be error",
+			args{
+				ServerRule{Action: "deny", IPCIDR: "1.2.3.0/33"},
+			},
+			ServerRule{},
+			true,
+		},
+		{
+			"Invalid pattern should be error",
+			args{
+				ServerRule{Action: "deny", HostNamePattern: "public-vpn-[1"},
+			},
+			ServerRule{},
+			true,
+		},
+		{
+			"Expiry in the past should be error",
+			args{
+				ServerRule{Action: "deny", CountryCode: "JP", ExpiresAt: &past},
+			},
+			ServerRule{},
+			true,
+		},
+		{
+			"Criteria are normalized",
+			args{
+				ServerRule{Action: " Deny", IPCIDR: "1.2.3.4 ", HostNamePattern: "Public-VPN-*", CountryCode: "kr"},
+			},
+			ServerRule{Action: "deny", IPCIDR: "1.2.3.4", HostNamePattern: "public-vpn-*", CountryCode: "KR"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.args.rule
+			err := validateServerRule(&rule, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateServerRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(rule, tt.want) {
+				t.Errorf("validateServerRule() = %+v, want %+v", rule, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyServerRules(t *testing.T) {
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	servers := []*VPNServer{
+		{ID: 1, HostName: "public-vpn-1", IP: "1.2.3.4", Operator: "Abuse Inc.", Country: Country{Code: "JP"}},
+		{ID: 2, HostName: "public-vpn-2", IP: "1.2.4.5", Operator: "Trusted", Country: Country{Code: "JP"}},
+		{ID: 3, HostName: "vpn-3.example.com", IP: "2001:db8::1", Country: Country{Code: "KR"}},
+	}
+	type args struct {
+		rules []*ServerRule
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       []int32
+		wantDenied int
+	}{
+		{
+			"No rules keep every server",
+			args{
+				nil,
+			},
+			[]int32{1, 2, 3},
+			0,
+		},
+		{
+			"Deny by CIDR",
+			args{
+				[]*ServerRule{{ID: 1, Action: "deny", IPCIDR: "1.2.0.0/16"}},
+			},
+			[]int32{3},
+			2,
+		},
+		{
+			"Deny by operator pattern and IPv6 address",
+			args{
+				[]*ServerRule{
+					{ID: 1, Action: "deny", OperatorPattern: "abuse*"},
+					{ID: 2, Action: "deny", IPCIDR: "2001:db8::1"},
+				},
+			},
+			[]int32{2},
+			2,
+		},
+		{
+			"Allow overrides deny",
+			args{
+				[]*ServerRule{
+					{ID: 1, Action: "deny", CountryCode: "JP"},
+					{ID: 2, Action: "allow", HostNamePattern: "public-vpn-2"},
+				},
+			},
+			[]int32{2, 3},
+			1,
+		},
+		{
+			"Expired rules are ignored",
+			args{
+				[]*ServerRule{{ID: 1, Action: "deny", CountryCode: "JP", ExpiresAt: &expired}},
+			},
+			[]int32{1, 2, 3},
+			0,
+		},
+		{
+			"Every criterion must match",
+			args{
+				[]*ServerRule{{ID: 1, Action: "deny", CountryCode: "JP", HostNamePattern: "*.example.com"}},
+			},
+			[]int32{1, 2, 3},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, denied := applyServerRules(servers, tt.args.rules, now)
+			var got []int32
+			for _, srv := range kept {
+				got = append(got, srv.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyServerRules() = %v, want %v", got, tt.want)
+			}
+			if len(denied) != tt.wantDenied {
+				t.Errorf("applyServerRules() denied = %+v, want %d", denied, tt.wantDenied)
+			}
+		})
+	}
+}
@@ -2,20 +2,23 @@ package vpn
 
 import (
 	"context"
-	"database/sql"
 	"flag"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"squirrel-srv/internal/vpn/protocol/grpc"
 	"squirrel-srv/internal/vpn/protocol/restful"
-	v1 "squirrel-srv/pkg/api/v1"
+	"squirrel-srv/pkg/clientip"
+	"squirrel-srv/pkg/fetch"
+	"squirrel-srv/pkg/geoip"
 	"squirrel-srv/pkg/logger"
+	"squirrel-srv/pkg/version"
 	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jmoiron/sqlx"
 	"github.com/robfig/cron"
 	"google.golang.org/grpc/credentials"
@@ -35,6 +38,53 @@ var (
 	kEnvDBSchema   = "DB_SCHEMA"
 	kEnvDBPort     = "DB_PORT"
 
+	kEnvDBSSLMode     = "DB_SSL_MODE"
+	kEnvDBSSLRootCert = "DB_SSL_ROOT_CERT"
+
+	kEnvDBFixturePath = "DB_FIXTURE_PATH"
+
+	kEnvAutoMigrate = "AUTO_MIGRATE"
+
+	kEnvDBQueryTimeout = "DB_QUERY_TIMEOUT"
+	kEnvDBTxTimeout    = "DB_TX_TIMEOUT"
+	kEnvDBCacheTTL     = "DB_CACHE_TTL"
+
+	kEnvCrawlerSources  = "CRAWLER_SOURCES"
+	kEnvCrawlerFilePath = "CRAWLER_FILE_PATH"
+
+	kEnvCrawlerVPNGateURLs  = "CRAWLER_VPNGATE_URLS"
+	kEnvCrawlerTimeout      = "CRAWLER_TIMEOUT"
+	kEnvCrawlerMaxSize      = "CRAWLER_MAX_SIZE"
+	kEnvCrawlerRetries      = "CRAWLER_RETRIES"
+	kEnvCrawlerRetryBackoff = "CRAWLER_RETRY_BACKOFF"
+	kEnvCrawlerProxy        = "CRAWLER_PROXY"
+	kEnvCrawlerUserAgent    = "CRAWLER_USER_AGENT"
+
+	kEnvCrawlerArchiveDir     = "CRAWLER_ARCHIVE_DIR"
+	kEnvCrawlerArchiveMaxAge  = "CRAWLER_ARCHIVE_MAX_AGE"
+	kEnvCrawlerArchiveMaxSize = "CRAWLER_ARCHIVE_MAX_SIZE"
+	kEnvCrawlerBatchSize      = "CRAWLER_BATCH_SIZE"
+
+	kEnvCrawlerMinServers        = "CRAWLER_MIN_SERVERS"
+	kEnvCrawlerMaxDropPercent    = "CRAWLER_MAX_DROP_PERCENT"
+	kEnvCrawlerMaxDropRejections = "CRAWLER_MAX_DROP_REJECTIONS"
+	kEnvCrawlerMinCountries      = "CRAWLER_MIN_COUNTRIES"
+	kEnvCrawlerMaxRejectedRatio  = "CRAWLER_MAX_REJECTED_RATIO"
+
+	kEnvLeaderLeaseTTL = "LEADER_LEASE_TTL"
+
+	kEnvMetricsRetention = "METRICS_RETENTION"
+
+	kEnvProbeConcurrency = "PROBE_CONCURRENCY"
+	kEnvProbeTimeout     = "PROBE_TIMEOUT"
+	kEnvProbeMaxFailures = "PROBE_MAX_FAILURES"
+
+	kEnvRankWeights = "RANK_WEIGHTS"
+
+	kEnvGeoIPDBPath = "GEOIP_DB_PATH"
+
+	kEnvTrustedProxies = "TRUSTED_PROXIES"
+
 	kEnvLogLevel      = "LOG_LEVEL"
 	kEnvLogTimeFormat = "LOG_TIME_FORMAT"
 )
@@ -70,6 +120,88 @@ type Config struct {
 	DBSchema string
 	// DBPort
 	DBPort string
+	// DBSSLMode is sslmode of PostgreSQL connections: disable, require, verify-ca or verify-full
+	DBSSLMode string
+	// DBSSLRootCert is path of the CA certificate PostgreSQL server certificates are verified with
+	DBSSLRootCert string
+	// DBFixturePath is path of the JSON fixture the memory database is seeded with
+	DBFixturePath string
+	// Dev runs the service on the memory database
+	Dev bool
+	// AutoMigrate applies the pending migrations on start
+	AutoMigrate bool
+	// DBQueryTimeout is time limit of a single database query, 0 disables it
+	DBQueryTimeout time.Duration
+	// DBTxTimeout is time limit of a database transaction, 0 disables it
+	DBTxTimeout time.Duration
+	// DBCacheTTL is how long the country and VPN server lists are cached, 0 disables the cache
+	DBCacheTTL time.Duration
+
+	// Crawler parameters section
+	// CrawlerSources is comma separated list of enabled crawler sources e.g. vpngate,file
+	CrawlerSources string
+	// CrawlerFilePath is path of the JSON or CSV file read by the file source
+	CrawlerFilePath string
+	// CrawlerVPNGateURLs is comma separated list of VPNGate mirrors tried in order
+	CrawlerVPNGateURLs string
+	// CrawlerTimeout is time limit of a single feed download attempt
+	CrawlerTimeout time.Duration
+	// CrawlerMaxSize is the largest accepted feed in bytes
+	CrawlerMaxSize int64
+	// CrawlerRetries is number of retries of a failed feed download per mirror
+	CrawlerRetries int
+	// CrawlerRetryBackoff is the wait before the first retry, it doubles on every retry
+	CrawlerRetryBackoff time.Duration
+	// CrawlerProxy is URL of the HTTP proxy feeds are downloaded through
+	CrawlerProxy string
+	// CrawlerUserAgent is User-Agent of feed downloads
+	CrawlerUserAgent string
+	// CrawlerArchiveDir is directory the raw feeds are archived in, feeds are not archived when it is empty
+	CrawlerArchiveDir string
+	// CrawlerArchiveMaxAge is how long archived feeds are kept
+	CrawlerArchiveMaxAge time.Duration
+	// CrawlerArchiveMaxSize is the largest size of the feed archive in bytes
+	CrawlerArchiveMaxSize int64
+	// CrawlerBatchSize is the number of servers upserted by a single statement
+	CrawlerBatchSize int
+	// CrawlerMinServers is the least number of servers of an accepted crawl, 0 disables the check
+	CrawlerMinServers int
+	// CrawlerMaxDropPercent is the largest accepted drop of the number of servers relative to
+	// the previous crawl, 0 disables the check
+	CrawlerMaxDropPercent float64
+	// CrawlerMaxDropRejections is the number of consecutive crawls rejected by the max drop check after
+	// which the drop is accepted as lasting, 0 keeps rejecting it
+	CrawlerMaxDropRejections int
+	// CrawlerMinCountries is the least number of countries of an accepted crawl, 0 disables the check
+	CrawlerMinCountries int
+	// CrawlerMaxRejectedRatio is the largest accepted ratio of rows rejected by the parser, 0 disables the check
+	CrawlerMaxRejectedRatio float64
+
+	// LeaderLeaseTTL is how long the leader election lease lasts without being renewed,
+	// only the leader replica runs scheduled jobs
+	LeaderLeaseTTL time.Duration
+
+	// MetricsRetention is how long the metric history of VPN servers is kept
+	MetricsRetention time.Duration
+
+	// Prober parameters section
+	// ProbeConcurrency is number of VPN servers probed at the same time
+	ProbeConcurrency int
+	// ProbeTimeout is how long a probe waits for the OpenVPN port to answer
+	ProbeTimeout time.Duration
+	// ProbeMaxFailures is number of consecutive failed probes after which a server is hidden
+	ProbeMaxFailures int
+
+	// RankWeights are the weights of speed, ping, sessions, uptime and reachability in the ranker score
+	RankWeights RankWeights
+
+	// GeoIPDBPath is path of the MaxMind format .mmdb file locating clients and servers,
+	// nearest servers are unavailable when it is empty
+	GeoIPDBPath string
+
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For and X-Real-IP headers are read,
+	// the headers are ignored when it is empty
+	TrustedProxies clientip.Proxies
 
 	// Log parameters section
 	// LogLevel is global log level: Debug(-1), Info(0), Warn(1), Error(2), DPanic(3), Panic(4), Fatal(5)
@@ -78,31 +210,16 @@ type Config struct {
 	LogTimeFormat string
 }
 
-// RunServer runs gRPC server and HTTP gateway
-func RunServer() error {
-	ctx := context.Background()
-
-	logLevelEnv, _ := strconv.Atoi(os.Getenv(kEnvLogLevel))
+// RunServer runs gRPC server and HTTP gateway, args are the command line flags
+func RunServer(args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// get configuration
-	var cfg Config
-	flag.BoolVar(&cfg.TLS, "tls", false, "gRPC TLS or plain TCP")
-	flag.StringVar(&cfg.TLSCertificate, "tls-cert", "", "TLS certificate file")
-	flag.StringVar(&cfg.TLSKey, "tls-key", "", "TLS key file")
-	flag.StringVar(&cfg.PrivateKey, "private-key", os.Getenv(kEnvPrivateKey), "Private key value")
-	flag.StringVar(&cfg.PublicKey, "public-key", os.Getenv(kEnvPublicKey), "Public key value")
-	flag.StringVar(&cfg.GRPCPort, "grpc-port", os.Getenv(kEnvGRPCPort), "gRPC port to bind")
-	flag.StringVar(&cfg.HTTPPort, "http-port", os.Getenv(kEnvHTTPPort), "HTTP port to bind")
-	flag.StringVar(&cfg.DBDriver, "db-driver", os.Getenv(kEnvDBDriver), "Database driver")
-	flag.StringVar(&cfg.DBHost, "db-host", os.Getenv(kEnvDBHost), "Database host")
-	flag.StringVar(&cfg.DBUser, "db-user", os.Getenv(kEnvDBUser), "Database user")
-	flag.StringVar(&cfg.DBPassword, "db-password", os.Getenv(kEnvDBPassword), "Database password")
-	flag.StringVar(&cfg.DBSchema, "db-schema", os.Getenv(kEnvDBSchema), "Database schema")
-	flag.StringVar(&cfg.DBPort, "db-port", os.Getenv(kEnvDBPort), "Database port")
-	flag.IntVar(&cfg.LogLevel, "log-level", logLevelEnv, "Global log level")
-	flag.StringVar(&cfg.LogTimeFormat, "log-time-format", os.Getenv(kEnvLogTimeFormat),
-		"Print time format for logger e.g. 2006-01-02T15:04:05Z07:00")
-	flag.Parse()
+	cfg, err := loadConfig(flag.NewFlagSet("squirrel", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
 
 	if len(cfg.GRPCPort) == 0 {
 		return fmt.Errorf("invalid TCP port for gRPC server: '%s'", cfg.GRPCPort)
@@ -117,49 +234,18 @@ func RunServer() error {
 		return fmt.Errorf("failed to initialize logger: %v", err)
 	}
 
-	// add MySQL driver specific parameter to parse date/time
-	// Drop it for another database
-	param := "parseTime=true&multiStatements=true"
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
-		cfg.DBUser,
-		cfg.DBPassword,
-		cfg.DBHost,
-		cfg.DBPort,
-		cfg.DBSchema,
-		param)
-	if cfg.DBDriver == "sqlite3" {
-		dsn = cfg.DBSchema
-	}
-
-	db, err := sqlx.Connect(cfg.DBDriver, dsn)
+	repo, closeRepo, err := openRepository(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %v", err)
+		return err
 	}
-	defer db.Close()
+	defer closeRepo()
 
-	dbMigrate, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return fmt.Errorf("failed to connect migrate database: %v", err)
-	}
-
-	driver, err := mysql.WithInstance(dbMigrate, &mysql.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create driver migrate database: %v", err)
-	}
-	m, err := migrate.NewWithDatabaseInstance(
-		"file:migrations",
-		"mysql", driver)
-	if err != nil {
-		return fmt.Errorf("failed to create migrate database instance: %v", err)
-	}
-	err = m.Steps(1)
-	if err != nil {
-		logger.Log.Warn("migrate database err ->" + err.Error())
+	if cfg.AutoMigrate && cfg.DBDriver != memoryDriverName {
+		if err := autoMigrate(cfg); err != nil {
+			return fmt.Errorf("failed to migrate database: %v", err)
+		}
 	}
 
-	_ = dbMigrate.Close()
-
 	// TLS
 	var creds credentials.TransportCredentials
 	if cfg.TLS == true {
@@ -175,23 +261,386 @@ func RunServer() error {
 		}
 	}
 
-	v1API := NewServiceServer(db)
+	sources, err := NewSources(cfg)
+	if err != nil {
+		return err
+	}
+
+	geo, err := openGeoIP(cfg)
+	if err != nil {
+		return err
+	}
+	if geo != nil {
+		defer geo.Close()
+	}
+
+	var archive *Archive
+	if len(cfg.CrawlerArchiveDir) > 0 {
+		archive = NewArchive(cfg.CrawlerArchiveDir, cfg.CrawlerArchiveMaxAge, cfg.CrawlerArchiveMaxSize)
+	}
+
+	crawler := NewCrawler(repo, sources, geo, archive, cfg)
+	prober := NewProber(repo, cfg.ProbeConcurrency, cfg.ProbeTimeout)
+	// only the elected replica crawls and runs scheduled jobs
+	elector := NewElector(repo, schedulerLease, cfg.LeaderLeaseTTL)
+	go elector.Run(ctx)
+
+	v1API := newServiceServer(repo, crawler, elector, geo, cfg)
+	refreshSnapshot := func() {
+		if err := v1API.refreshSnapshot(ctx); err != nil {
+			logger.Log.Warn("refresh response snapshot error: " + err.Error())
+		}
+	}
+	// lists are read from the repository until the first snapshot is built
+	go refreshSnapshot()
 
 	c := cron.New()
 	defer c.Stop()
-	_ = c.AddFunc("@every 1m", func() {
-		crawled, err := v1API.VPNGateCrawler(ctx, &v1.VPNGateCrawlerRequest{Api: apiVersion})
+	_ = c.AddFunc("@every 1m", elector.LeaderOnly(ctx, func(ctx context.Context) {
+		// failing sources are logged by the crawler, they do not prevent probing
+		if _, err := crawler.CrawlAll(ctx); err == ErrCrawlInProgress {
+			// cron does not wait for the previous run, the servers are probed by the next run
+			logger.Log.Info("scheduled crawl skipped, " + err.Error())
+			return
+		}
+		results, err := prober.ProbeAll(ctx)
+		refreshSnapshot()
 		if err != nil {
-			logger.Log.Warn("crawl error: " + err.Error())
+			logger.Log.Warn("probe error: " + err.Error())
+			return
+		}
+		reachable := 0
+		for _, r := range results {
+			if r.Reachable {
+				reachable++
+			}
+		}
+		logger.Log.Info("probed " + strconv.Itoa(len(results)) + " servers, " + strconv.Itoa(reachable) + " reachable")
+	}))
+	_ = c.AddFunc("@hourly", elector.LeaderOnly(ctx, func(ctx context.Context) {
+		pruned, err := repo.PruneServerMetrics(ctx, time.Now().Add(-cfg.MetricsRetention))
+		if err != nil {
+			logger.Log.Warn("prune server metrics error: " + err.Error())
+			return
+		}
+		logger.Log.Info("pruned " + strconv.FormatInt(pruned, 10) + " server metrics")
+	}))
+	// the other replicas pick up the crawls of the leader on their next refresh
+	_ = c.AddFunc("@every 1m", func() {
+		if !elector.IsLeader() {
+			refreshSnapshot()
 		}
-		logger.Log.Info("crawled success " + strconv.Itoa(len(crawled.Data)) + " items")
 	})
+	if cache, ok := repo.(*cacheRepository); ok {
+		// every replica serves clients from its own cache
+		_ = c.AddFunc("@every 10m", func() {
+			stats := cache.Stats()
+			logger.Log.Info("repository cache hits " + strconv.FormatInt(stats.Hits, 10) +
+				", misses " + strconv.FormatInt(stats.Misses, 10))
+		})
+	}
 	c.Start()
 
 	// run HTTP gateway
 	go func() {
-		_ = restful.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, creds)
+		_ = restful.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, creds, cfg.TrustedProxies)
 	}()
 
 	return grpc.RunServer(ctx, v1API, cfg.GRPCPort, creds)
 }
+
+// loadConfig registers the configuration flags on fs and parses args, flags default to environment
+// variables. Callers may register their own flags on fs beforehand.
+func loadConfig(fs *flag.FlagSet, args []string) (Config, error) {
+	logLevelEnv, _ := strconv.Atoi(os.Getenv(kEnvLogLevel))
+
+	var cfg Config
+	fs.BoolVar(&cfg.TLS, "tls", false, "gRPC TLS or plain TCP")
+	fs.StringVar(&cfg.TLSCertificate, "tls-cert", "", "TLS certificate file")
+	fs.StringVar(&cfg.TLSKey, "tls-key", "", "TLS key file")
+	fs.StringVar(&cfg.PrivateKey, "private-key", os.Getenv(kEnvPrivateKey), "Private key value")
+	fs.StringVar(&cfg.PublicKey, "public-key", os.Getenv(kEnvPublicKey), "Public key value")
+	fs.StringVar(&cfg.GRPCPort, "grpc-port", os.Getenv(kEnvGRPCPort), "gRPC port to bind")
+	fs.StringVar(&cfg.HTTPPort, "http-port", os.Getenv(kEnvHTTPPort), "HTTP port to bind")
+	fs.StringVar(&cfg.DBDriver, "db-driver", os.Getenv(kEnvDBDriver), "Database driver")
+	fs.StringVar(&cfg.DBHost, "db-host", os.Getenv(kEnvDBHost), "Database host")
+	fs.StringVar(&cfg.DBUser, "db-user", os.Getenv(kEnvDBUser), "Database user")
+	fs.StringVar(&cfg.DBPassword, "db-password", os.Getenv(kEnvDBPassword), "Database password")
+	fs.StringVar(&cfg.DBSchema, "db-schema", os.Getenv(kEnvDBSchema), "Database schema")
+	fs.StringVar(&cfg.DBPort, "db-port", os.Getenv(kEnvDBPort), "Database port")
+	fs.StringVar(&cfg.DBSSLMode, "db-ssl-mode", envOrDefault(kEnvDBSSLMode, "require"),
+		"PostgreSQL sslmode: disable, require, verify-ca or verify-full")
+	fs.StringVar(&cfg.DBSSLRootCert, "db-ssl-root-cert", os.Getenv(kEnvDBSSLRootCert),
+		"CA certificate file PostgreSQL server certificates are verified with")
+	fs.StringVar(&cfg.DBFixturePath, "db-fixture", os.Getenv(kEnvDBFixturePath),
+		"JSON fixture file the memory database is seeded with")
+	fs.BoolVar(&cfg.Dev, "dev", false, "Development mode, the database is in memory")
+	fs.BoolVar(&cfg.AutoMigrate, "auto-migrate", boolEnvOrDefault(kEnvAutoMigrate, false),
+		"Apply the pending database migrations on start, it fails when the schema is dirty")
+	fs.DurationVar(&cfg.DBQueryTimeout, "db-query-timeout", durationEnvOrDefault(kEnvDBQueryTimeout, 5*time.Second),
+		"Time limit of a single database query, 0 disables it")
+	fs.DurationVar(&cfg.DBTxTimeout, "db-tx-timeout", durationEnvOrDefault(kEnvDBTxTimeout, time.Minute),
+		"Time limit of a database transaction, 0 disables it")
+	fs.DurationVar(&cfg.DBCacheTTL, "db-cache-ttl", durationEnvOrDefault(kEnvDBCacheTTL, 30*time.Second),
+		"How long the country and VPN server lists are cached, 0 disables the cache")
+	fs.StringVar(&cfg.CrawlerSources, "crawler-sources", envOrDefault(kEnvCrawlerSources, vpnGateSourceName),
+		"Comma separated list of enabled crawler sources: "+strings.Join(Sources(), ", "))
+	fs.StringVar(&cfg.CrawlerFilePath, "crawler-file-path", os.Getenv(kEnvCrawlerFilePath),
+		"JSON or CSV file read by the file crawler source")
+	fs.StringVar(&cfg.CrawlerVPNGateURLs, "crawler-vpngate-urls", envOrDefault(kEnvCrawlerVPNGateURLs, vpnGateAPIURL),
+		"Comma separated list of VPNGate mirrors tried in order")
+	fs.DurationVar(&cfg.CrawlerTimeout, "crawler-timeout", durationEnvOrDefault(kEnvCrawlerTimeout, 30*time.Second),
+		"Time limit of a single feed download attempt e.g. 30s")
+	fs.Int64Var(&cfg.CrawlerMaxSize, "crawler-max-size", int64(intEnvOrDefault(kEnvCrawlerMaxSize, 32<<20)),
+		"Largest accepted feed in bytes")
+	fs.IntVar(&cfg.CrawlerRetries, "crawler-retries", intEnvOrDefault(kEnvCrawlerRetries, 3),
+		"Number of retries of a failed feed download per mirror")
+	fs.DurationVar(&cfg.CrawlerRetryBackoff, "crawler-retry-backoff", durationEnvOrDefault(kEnvCrawlerRetryBackoff, time.Second),
+		"Wait before the first retry of a feed download, it doubles on every retry e.g. 1s")
+	fs.StringVar(&cfg.CrawlerProxy, "crawler-proxy", os.Getenv(kEnvCrawlerProxy),
+		"URL of the HTTP proxy feeds are downloaded through")
+	fs.StringVar(&cfg.CrawlerUserAgent, "crawler-user-agent", envOrDefault(kEnvCrawlerUserAgent, "squirrel-srv/"+version.Release),
+		"User-Agent of feed downloads")
+	fs.StringVar(&cfg.CrawlerArchiveDir, "crawler-archive-dir", os.Getenv(kEnvCrawlerArchiveDir),
+		"Directory the raw feeds are archived in, feeds are not archived when it is empty")
+	fs.DurationVar(&cfg.CrawlerArchiveMaxAge, "crawler-archive-max-age", durationEnvOrDefault(kEnvCrawlerArchiveMaxAge, 7*24*time.Hour),
+		"How long archived feeds are kept e.g. 168h")
+	fs.Int64Var(&cfg.CrawlerArchiveMaxSize, "crawler-archive-max-size", int64(intEnvOrDefault(kEnvCrawlerArchiveMaxSize, 1<<30)),
+		"Largest size of the feed archive in bytes")
+	fs.IntVar(&cfg.CrawlerBatchSize, "crawler-batch-size", intEnvOrDefault(kEnvCrawlerBatchSize, 200),
+		"Number of servers upserted by a single statement")
+	fs.IntVar(&cfg.CrawlerMinServers, "crawler-min-servers", intEnvOrDefault(kEnvCrawlerMinServers, 10),
+		"Least number of servers of an accepted crawl, 0 disables the check")
+	fs.Float64Var(&cfg.CrawlerMaxDropPercent, "crawler-max-drop-percent", floatEnvOrDefault(kEnvCrawlerMaxDropPercent, 50),
+		"Largest accepted drop in percent of the number of servers relative to the previous crawl, 0 disables the check")
+	fs.IntVar(&cfg.CrawlerMaxDropRejections, "crawler-max-drop-rejections", intEnvOrDefault(kEnvCrawlerMaxDropRejections, 10),
+		"Number of consecutive crawls rejected by the max drop check after which the drop is accepted, 0 keeps rejecting it")
+	fs.IntVar(&cfg.CrawlerMinCountries, "crawler-min-countries", intEnvOrDefault(kEnvCrawlerMinCountries, 3),
+		"Least number of countries of an accepted crawl, 0 disables the check")
+	fs.Float64Var(&cfg.CrawlerMaxRejectedRatio, "crawler-max-rejected-ratio", floatEnvOrDefault(kEnvCrawlerMaxRejectedRatio, 0.2),
+		"Largest accepted ratio of feed rows rejected by the parser, 0 disables the check")
+	fs.DurationVar(&cfg.LeaderLeaseTTL, "leader-lease-ttl", durationEnvOrDefault(kEnvLeaderLeaseTTL, 30*time.Second),
+		"Leader election lease TTL e.g. 30s")
+	fs.DurationVar(&cfg.MetricsRetention, "metrics-retention", durationEnvOrDefault(kEnvMetricsRetention, 30*24*time.Hour),
+		"How long the metric history of VPN servers is kept e.g. 720h")
+	fs.IntVar(&cfg.ProbeConcurrency, "probe-concurrency", intEnvOrDefault(kEnvProbeConcurrency, 32),
+		"Number of VPN servers probed at the same time")
+	fs.DurationVar(&cfg.ProbeTimeout, "probe-timeout", durationEnvOrDefault(kEnvProbeTimeout, 3*time.Second),
+		"How long a probe waits for the OpenVPN port to answer e.g. 3s")
+	fs.IntVar(&cfg.ProbeMaxFailures, "probe-max-failures", intEnvOrDefault(kEnvProbeMaxFailures, 3),
+		"Number of consecutive failed probes after which a VPN server is hidden")
+	fs.StringVar(&cfg.GeoIPDBPath, "geoip-db-path", os.Getenv(kEnvGeoIPDBPath),
+		"MaxMind format .mmdb file locating clients and servers e.g. GeoLite2-City.mmdb")
+	trustedProxies := fs.String("trusted-proxies", os.Getenv(kEnvTrustedProxies),
+		"Comma separated IP addresses and networks of the trusted reverse proxies e.g. 10.0.0.0/8")
+	rankWeights := fs.String("rank-weights", envOrDefault(kEnvRankWeights, defaultRankWeights),
+		"Comma separated weights of the ranker factors e.g. "+defaultRankWeights)
+	fs.IntVar(&cfg.LogLevel, "log-level", logLevelEnv, "Global log level")
+	fs.StringVar(&cfg.LogTimeFormat, "log-time-format", os.Getenv(kEnvLogTimeFormat),
+		"Print time format for logger e.g. 2006-01-02T15:04:05Z07:00")
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if cfg.Dev {
+		cfg.DBDriver = memoryDriverName
+	}
+
+	if cfg.LeaderLeaseTTL <= 0 {
+		return cfg, fmt.Errorf("invalid leader lease TTL: '%s'", cfg.LeaderLeaseTTL)
+	}
+
+	if cfg.ProbeMaxFailures <= 0 {
+		return cfg, fmt.Errorf("invalid probe max failures: '%d'", cfg.ProbeMaxFailures)
+	}
+	if cfg.DBCacheTTL < 0 {
+		return cfg, fmt.Errorf("invalid database cache TTL: '%s'", cfg.DBCacheTTL)
+	}
+	if cfg.DBQueryTimeout < 0 || cfg.DBTxTimeout < 0 {
+		return cfg, fmt.Errorf("invalid database timeouts: '%s' query, '%s' transaction", cfg.DBQueryTimeout, cfg.DBTxTimeout)
+	}
+	switch cfg.DBSSLMode {
+	case "disable", "require", "verify-ca", "verify-full":
+	default:
+		return cfg, fmt.Errorf("invalid database SSL mode: '%s'", cfg.DBSSLMode)
+	}
+	if cfg.CrawlerBatchSize <= 0 {
+		return cfg, fmt.Errorf("invalid crawler batch size: '%d'", cfg.CrawlerBatchSize)
+	}
+	if cfg.CrawlerMinServers < 0 || cfg.CrawlerMinCountries < 0 {
+		return cfg, fmt.Errorf("invalid crawler minimums: '%d' servers, '%d' countries", cfg.CrawlerMinServers, cfg.CrawlerMinCountries)
+	}
+	if cfg.CrawlerMaxDropPercent < 0 || cfg.CrawlerMaxDropPercent > 100 {
+		return cfg, fmt.Errorf("invalid crawler max drop percent: '%v'", cfg.CrawlerMaxDropPercent)
+	}
+	if cfg.CrawlerMaxDropRejections < 0 {
+		return cfg, fmt.Errorf("invalid crawler max drop rejections: '%d'", cfg.CrawlerMaxDropRejections)
+	}
+	if cfg.CrawlerMaxRejectedRatio < 0 || cfg.CrawlerMaxRejectedRatio > 1 {
+		return cfg, fmt.Errorf("invalid crawler max rejected ratio: '%v'", cfg.CrawlerMaxRejectedRatio)
+	}
+
+	weights, err := ParseRankWeights(*rankWeights)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.RankWeights = weights
+
+	proxies, err := clientip.ParseProxies(*trustedProxies)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.TrustedProxies = proxies
+	return cfg, nil
+}
+
+// databaseDSN returns the data source name of the database
+func databaseDSN(cfg Config) string {
+	if cfg.DBDriver == postgresDriverName {
+		query := url.Values{}
+		query.Set("sslmode", cfg.DBSSLMode)
+		if len(cfg.DBSSLRootCert) > 0 {
+			query.Set("sslrootcert", cfg.DBSSLRootCert)
+		}
+		u := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.DBUser, cfg.DBPassword),
+			Host:     net.JoinHostPort(cfg.DBHost, cfg.DBPort),
+			Path:     "/" + cfg.DBSchema,
+			RawQuery: query.Encode(),
+		}
+		return u.String()
+	}
+	if cfg.DBDriver == sqliteDriverName {
+		// DB_SCHEMA is path of the database file
+		if strings.Contains(cfg.DBSchema, "?") {
+			return cfg.DBSchema
+		}
+		return cfg.DBSchema + "?_busy_timeout=5000"
+	}
+	// add MySQL driver specific parameter to parse date/time
+	// Drop it for another database
+	param := "parseTime=true&multiStatements=true"
+
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
+		cfg.DBUser,
+		cfg.DBPassword,
+		cfg.DBHost,
+		cfg.DBPort,
+		cfg.DBSchema,
+		param)
+}
+
+// autoMigrate applies every pending migration to the configured database
+func autoMigrate(cfg Config) error {
+	m, err := openMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return migrateUp(m)
+}
+
+// openDatabase connects to the database
+func openDatabase(cfg Config) (*sqlx.DB, error) {
+	db, err := sqlx.Connect(cfg.DBDriver, databaseDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	if cfg.DBDriver == sqliteDriverName {
+		// SQLite has a single writer, one connection avoids "database is locked" errors
+		db.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// openRepository opens the repository of the configured database driver, the memory database is
+// seeded from the fixture file when one is configured. Queries are bounded by the configured timeouts
+// and the lists read by clients are cached. The returned function closes the repository.
+func openRepository(cfg Config) (Repository, func() error, error) {
+	var repo Repository
+	closeRepo := func() error { return nil }
+	if cfg.DBDriver == memoryDriverName {
+		repo = NewMemoryRepository()
+		if len(cfg.DBFixturePath) > 0 {
+			if err := seedMemoryRepository(context.Background(), repo, cfg.DBFixturePath); err != nil {
+				return nil, nil, fmt.Errorf("failed to seed memory database: %v", err)
+			}
+		}
+	} else {
+		db, err := openDatabase(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		repo = NewRepository(db)
+		closeRepo = db.Close
+	}
+	repo = NewTimeoutRepository(repo, cfg.DBQueryTimeout, cfg.DBTxTimeout)
+	if cfg.DBCacheTTL > 0 {
+		repo = NewCacheRepository(repo, cfg.DBCacheTTL, cfg.DBQueryTimeout)
+	}
+	return repo, closeRepo, nil
+}
+
+// openGeoIP opens the GeoIP database, it returns nil when none is configured
+func openGeoIP(cfg Config) (*geoip.DB, error) {
+	if len(cfg.GeoIPDBPath) == 0 {
+		return nil, nil
+	}
+	geo, err := geoip.Open(cfg.GeoIPDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %v", err)
+	}
+	return geo, nil
+}
+
+// fetchConfig returns the configuration of the HTTP client downloading feeds
+func (cfg Config) fetchConfig() fetch.Config {
+	return fetch.Config{
+		Timeout:   cfg.CrawlerTimeout,
+		MaxSize:   cfg.CrawlerMaxSize,
+		Retries:   cfg.CrawlerRetries,
+		Backoff:   cfg.CrawlerRetryBackoff,
+		Proxy:     cfg.CrawlerProxy,
+		UserAgent: cfg.CrawlerUserAgent,
+	}
+}
+
+// envOrDefault returns value of the environment variable key or def when it is empty
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// boolEnvOrDefault parses the environment variable key as a boolean or returns def when it is empty or invalid
+func boolEnvOrDefault(key string, def bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// durationEnvOrDefault parses the environment variable key as a duration or returns def when it is empty or invalid
+func durationEnvOrDefault(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// intEnvOrDefault parses the environment variable key as an integer or returns def when it is empty or invalid
+func intEnvOrDefault(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// floatEnvOrDefault parses the environment variable key as a float or returns def when it is empty or invalid
+func floatEnvOrDefault(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,42 @@
+package vpn
+
+import (
+	"testing"
+)
+
+func Test_databaseDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			"MySQL DSN should parse time",
+			Config{DBDriver: "mysql", DBUser: "u", DBPassword: "p", DBHost: "db", DBPort: "3306", DBSchema: "vpn"},
+			"u:p@tcp(db:3306)/vpn?parseTime=true&multiStatements=true",
+		},
+		{
+			"PostgreSQL DSN should escape credentials and set sslmode",
+			Config{DBDriver: "postgres", DBUser: "u", DBPassword: "p@ss word", DBHost: "db", DBPort: "5432",
+				DBSchema: "vpn", DBSSLMode: "verify-full", DBSSLRootCert: "/certs/ca.pem"},
+			"postgres://u:p%40ss%20word@db:5432/vpn?sslmode=verify-full&sslrootcert=%2Fcerts%2Fca.pem",
+		},
+		{
+			"SQLite DSN should be the database file",
+			Config{DBDriver: "sqlite3", DBSchema: "data.db"},
+			"data.db?_busy_timeout=5000",
+		},
+		{
+			"SQLite DSN with options should be kept",
+			Config{DBDriver: "sqlite3", DBSchema: "file:data.db?mode=ro"},
+			"file:data.db?mode=ro",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := databaseDSN(tt.cfg); got != tt.want {
+				t.Errorf("databaseDSN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,23 +1,25 @@
 package vpn
 
 import (
-	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/awa/go-iap/appstore"
 	"github.com/golang/protobuf/ptypes"
-	"github.com/jmoiron/sqlx"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"io"
-	"io/ioutil"
-	"net/http"
 	"os"
 	"squirrel-srv/pkg/api/v1"
 	"squirrel-srv/pkg/auth"
-	"squirrel-srv/pkg/logger"
+	"squirrel-srv/pkg/clientip"
+	"squirrel-srv/pkg/geoip"
 	"squirrel-srv/pkg/version"
-	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -25,8 +27,34 @@ var (
 	apiVersion = "v1"
 )
 
+const (
+	// defaultCrawlRunsLimit is number of crawl runs listed when the request has no limit
+	defaultCrawlRunsLimit = 50
+)
+
 type serviceServer struct {
-	repo Repository
+	repo    Repository
+	crawler *Crawler
+	// elector lets only the leader crawl, every replica is the leader when it is nil
+	elector *Elector
+	// maxProbeFailures is number of consecutive failed probes after which a server is hidden
+	maxProbeFailures int32
+	ranker           *Ranker
+	// geo locates clients, ListNearestServers is unavailable when it is nil
+	geo *geoip.DB
+
+	// snapshot holds the published *responseSnapshot, refreshMu serializes its builds
+	// and snapshotMu guards snapshotGen which is bumped on every invalidation
+	snapshot    atomic.Value
+	refreshMu   sync.Mutex
+	snapshotMu  sync.Mutex
+	snapshotGen uint64
+	// rulesCheckedAt is when the server rules were last found unchanged since the snapshot was built,
+	// in Unix nanoseconds, rulesCheck merges the concurrent checks
+	rulesCheckedAt int64
+	rulesCheck     singleflight.Group
+	// snapshotUpdating is 1 while updateSnapshot builds a snapshot
+	snapshotUpdating int32
 }
 
 func (s *serviceServer) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
@@ -39,6 +67,19 @@ func (s *serviceServer) AuthFuncOverride(ctx context.Context, fullMethodName str
 			return ctx, nil
 		}
 	}
+	adminOnly := []string{
+		"/v1.Service/VPNGateCrawler",
+		"/v1.Service/ListCrawlRuns",
+		"/v1.Service/GetCrawlRun",
+		"/v1.Service/CreateServerRule",
+		"/v1.Service/ListServerRules",
+		"/v1.Service/DeleteServerRule",
+	}
+	for _, admin := range adminOnly {
+		if admin == fullMethodName {
+			return auth.VerifyAdminKey(ctx)
+		}
+	}
 	return auth.VerifyClientKey(ctx)
 }
 
@@ -78,10 +119,18 @@ func (s *serviceServer) VerifyAppleReceipt(ctx context.Context, req *v1.VerifyAp
 	}, nil
 }
 
-func (s *serviceServer) ListCountries(context.Context, *v1.ListCountriesRequest) (*v1.ListCountriesResponse, error) {
-	countries, err := s.repo.FindAllCountryHaveVPNServer()
+func (s *serviceServer) ListCountries(ctx context.Context, _ *v1.ListCountriesRequest) (*v1.ListCountriesResponse, error) {
+	if snap := s.servedSnapshot(); snap != nil {
+		return snap.countries, nil
+	}
+	return s.listCountries(ctx)
+}
+
+// listCountries lists the countries from the repository
+func (s *serviceServer) listCountries(ctx context.Context) (*v1.ListCountriesResponse, error) {
+	countries, err := s.repo.FindAllCountryHaveVPNServer(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "unknown error -> "+err.Error())
+		return nil, repositoryError(err)
 	}
 	var resCountries []*v1.Country
 	for _, c := range countries {
@@ -98,128 +147,559 @@ func (s *serviceServer) ListCountries(context.Context, *v1.ListCountriesRequest)
 	}, nil
 }
 
-func (s *serviceServer) ListVPNServers(_ context.Context, req *v1.ListVPNServerRequest) (*v1.ListVPNServerResponse, error) {
+func (s *serviceServer) ListVPNServers(ctx context.Context, req *v1.ListVPNServerRequest) (*v1.ListVPNServerResponse, error) {
+	if key, ok := snapshotKey(req); ok {
+		if snap := s.servedSnapshot(); snap != nil {
+			if res, ok := snap.servers[key]; ok {
+				return res, nil
+			}
+		}
+	}
+	return s.listVPNServers(ctx, req)
+}
+
+// listVPNServers lists the VPN servers of a request from the repository
+func (s *serviceServer) listVPNServers(ctx context.Context, req *v1.ListVPNServerRequest) (*v1.ListVPNServerResponse, error) {
+	if len(req.Sort) > 0 && req.Sort != sortBySpeed && req.Sort != sortByRank {
+		return nil, status.Error(codes.InvalidArgument, "unknown sort '"+req.Sort+"'")
+	}
+	query, err := s.vpnServerQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	if req.Sort != sortByRank {
+		// one more server tells whether there is a next page
+		query.Limit = uint64(pageSize) + 1
+	}
+	// servers denied by a rule created since the last crawl are left out by the query so pages are
+	// full and counted without them
+	if query.ExcludeIDs, err = s.deniedServerIDs(ctx); err != nil {
+		return nil, err
+	}
+	vpns, total, err := s.repo.FindVPNServers(ctx, query)
+	if err != nil {
+		if err == ErrCountryNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, repositoryError(err)
+	}
+	var nextPageToken string
+	if query.Limit > 0 && len(vpns) > pageSize {
+		vpns = vpns[:pageSize]
+		nextPageToken = encodePageToken(query.SortBy, query.order(), vpns[pageSize-1])
+	}
+	var resVPNs []*v1.VPNServer
+	if req.Sort == sortByRank {
+		// every server is ranked, only the best ones are listed
+		ranked := s.ranker.Rank(vpns)
+		if len(ranked) > pageSize {
+			ranked = ranked[:pageSize]
+		}
+		for _, v := range ranked {
+			resVPN := s.vpnEntityToResponse(v.VPNServer)
+			resVPN.Rank = v.Score
+			resVPNs = append(resVPNs, resVPN)
+		}
+	} else {
+		for _, v := range vpns {
+			resVPNs = append(resVPNs, s.vpnEntityToResponse(v))
+		}
+	}
+
+	return &v1.ListVPNServerResponse{
+		Api:           apiVersion,
+		Data:          resVPNs,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
+	}, nil
+}
+
+// vpnServerQuery validates the filters, sort order and page token of a request and returns its query
+func (s *serviceServer) vpnServerQuery(req *v1.ListVPNServerRequest) (VPNServerQuery, error) {
+	query := VPNServerQuery{
+		CountryCode: req.CountryCode,
+		MinSpeed:    req.MinSpeed,
+		MaxPing:     req.MaxPing,
+		MaxSessions: req.MaxSessions,
+		Protocol:    req.Protocol,
+		Operator:    req.Operator,
+		SortBy:      req.SortBy,
+	}
+	if !req.IncludeUnreachable {
+		query.MaxProbeFailures = s.maxProbeFailures
+	}
+	if len(query.Protocol) > 0 && query.Protocol != "tcp" && query.Protocol != "udp" {
+		return query, status.Error(codes.InvalidArgument, "unknown protocol '"+query.Protocol+"'")
+	}
+	if req.Sort == sortByRank {
+		if len(req.SortBy) > 0 || len(req.Order) > 0 || len(req.PageToken) > 0 {
+			return query, status.Error(codes.InvalidArgument, "lists sorted by rank are not paginated nor sorted by sortBy")
+		}
+		query.SortBy = sortBySpeed
+		query.Desc = true
+		return query, nil
+	}
+	if len(query.SortBy) == 0 {
+		query.SortBy = sortBySpeed
+	}
+	if _, ok := sortColumns[query.SortBy]; !ok {
+		return query, status.Error(codes.InvalidArgument, "unknown sortBy '"+req.SortBy+"'")
+	}
+	order := req.Order
+	if len(order) == 0 {
+		order = defaultOrder(query.SortBy)
+	}
+	if order != orderAsc && order != orderDesc {
+		return query, status.Error(codes.InvalidArgument, "unknown order '"+req.Order+"'")
+	}
+	query.Desc = order == orderDesc
+	if len(req.PageToken) > 0 {
+		after, err := decodePageToken(req.PageToken, query.SortBy, order)
+		if err != nil {
+			return query, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.After = after
+	}
+	return query, nil
+}
+
+func (s *serviceServer) ListRecommendedServers(ctx context.Context, req *v1.ListRecommendedServersRequest) (*v1.ListRecommendedServersResponse, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultRecommendedLimit
+	}
+	if limit > maxRecommendedLimit {
+		limit = maxRecommendedLimit
+	}
+	vpns, err := s.findVPNServers(ctx, req.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+	ranked := s.ranker.Rank(filterReachable(vpns, s.maxProbeFailures))
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	var resVPNs []*v1.VPNServer
+	for _, v := range ranked {
+		resVPN := s.vpnEntityToResponse(v.VPNServer)
+		resVPN.Rank = v.Score
+		resVPNs = append(resVPNs, resVPN)
+	}
+	return &v1.ListRecommendedServersResponse{
+		Api:  apiVersion,
+		Data: resVPNs,
+	}, nil
+}
+
+func (s *serviceServer) ListNearestServers(ctx context.Context, req *v1.ListNearestServersRequest) (*v1.ListNearestServersResponse, error) {
+	if s.geo == nil {
+		return nil, status.Error(codes.FailedPrecondition, "GeoIP database is not configured")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultRecommendedLimit
+	}
+	if limit > maxRecommendedLimit {
+		limit = maxRecommendedLimit
+	}
+	vpns, err := s.findVPNServers(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	ranked := s.ranker.Rank(filterReachable(vpns, s.maxProbeFailures))
+
+	// clients that can not be located e.g. from a private network get the best ranked servers
+	var resVPNs []*v1.VPNServer
+	var countryCode string
+	loc, err := s.geo.Lookup(clientip.FromContext(ctx))
+	if err != nil {
+		if len(ranked) > limit {
+			ranked = ranked[:limit]
+		}
+		for _, v := range ranked {
+			resVPN := s.vpnEntityToResponse(v.VPNServer)
+			resVPN.Rank = v.Score
+			resVPNs = append(resVPNs, resVPN)
+		}
+	} else {
+		countryCode = loc.CountryCode
+		nearest := sortNearest(ranked, loc.Latitude, loc.Longitude)
+		if len(nearest) > limit {
+			nearest = nearest[:limit]
+		}
+		for _, v := range nearest {
+			resVPN := s.vpnEntityToResponse(v.VPNServer)
+			resVPN.Rank = v.Score
+			if v.DistanceKm >= 0 {
+				resVPN.DistanceKm = v.DistanceKm
+			}
+			resVPNs = append(resVPNs, resVPN)
+		}
+	}
+	return &v1.ListNearestServersResponse{
+		Api:         apiVersion,
+		CountryCode: countryCode,
+		Data:        resVPNs,
+	}, nil
+}
+
+// findVPNServers finds the VPN servers of a country or of every country when countryCode is empty,
+// the servers denied by server rules are left out
+func (s *serviceServer) findVPNServers(ctx context.Context, countryCode string) ([]*VPNServer, error) {
 	var vpns []*VPNServer
 	var err error
-	if len(req.CountryCode) == 0 {
-		vpns, err = s.repo.FindAllVPNServer()
+	if len(countryCode) == 0 {
+		vpns, err = s.repo.FindAllVPNServer(ctx)
 		if err != nil {
-			return nil, status.Error(codes.Unknown, "unknown error -> "+err.Error())
+			return nil, repositoryError(err)
 		}
 	} else {
-		vpns, err = s.repo.FindVPNServerByCountryCode(req.CountryCode)
+		vpns, err = s.repo.FindVPNServerByCountryCode(ctx, countryCode)
 		if err != nil {
 			if err == ErrCountryNotFound {
 				return nil, status.Error(codes.NotFound, err.Error())
 			}
-			return nil, status.Error(codes.Unknown, "unknown error -> "+err.Error())
+			return nil, repositoryError(err)
 		}
 	}
+	return s.applyServerRules(ctx, vpns)
+}
+
+// deniedServerIDs returns the ids of the servers denied by server rules, rules are read on every call
+// so changes take effect immediately
+func (s *serviceServer) deniedServerIDs(ctx context.Context) ([]int32, error) {
+	rules, err := s.repo.FindServerRules(ctx)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	now := time.Now()
+	if !hasDenyRule(rules, now) {
+		return nil, nil
+	}
+	targets, err := s.repo.FindRuleTargets(ctx)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	allowed, _ := applyServerRules(targets, rules, now)
+	kept := make(map[int32]bool, len(allowed))
+	for _, srv := range allowed {
+		kept[srv.ID] = true
+	}
+	var denied []int32
+	for _, srv := range targets {
+		if !kept[srv.ID] {
+			denied = append(denied, srv.ID)
+		}
+	}
+	return denied, nil
+}
+
+// applyServerRules leaves out the servers denied by server rules, rules are read on every call
+// so changes take effect immediately
+func (s *serviceServer) applyServerRules(ctx context.Context, vpns []*VPNServer) ([]*VPNServer, error) {
+	rules, err := s.repo.FindServerRules(ctx)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	allowed, _ := applyServerRules(vpns, rules, time.Now())
+	return allowed, nil
+}
+
+func (s *serviceServer) VPNGateCrawler(ctx context.Context, _ *v1.VPNGateCrawlerRequest) (*v1.VPNGateCrawlerResponse, error) {
+	crawlCtx := ctx
+	if s.elector != nil {
+		// a crawl of a replica that is not the leader would race with the scheduled crawls of the leader
+		termCtx, cancel, ok := s.elector.Term(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unavailable, "this replica is not the crawler leader, retry on the leader")
+		}
+		defer cancel()
+		crawlCtx = termCtx
+	}
+	servers, err := s.crawler.CrawlAll(crawlCtx)
+	if err != nil {
+		if err == ErrCrawlInProgress {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		if ctx.Err() == nil && crawlCtx.Err() != nil {
+			return nil, status.Error(codes.Aborted, "crawler leadership lost during the crawl")
+		}
+		return nil, status.Error(codes.Unknown, "crawl error -> "+err.Error())
+	}
+	// the lists are served from the published snapshot until the one of the crawl is built
+	s.updateSnapshot()
+	// the crawl stores the denied servers as well
+	servers, err = s.applyServerRules(ctx, servers)
+	if err != nil {
+		return nil, err
+	}
 	var resVPNs []*v1.VPNServer
-	for _, v := range vpns {
+	for _, v := range servers {
 		resVPNs = append(resVPNs, s.vpnEntityToResponse(v))
 	}
 
-	return &v1.ListVPNServerResponse{
+	return &v1.VPNGateCrawlerResponse{
 		Api: apiVersion,
 		Data: resVPNs,
 	}, nil
 }
 
-func (s *serviceServer) VPNGateCrawler(context.Context, *v1.VPNGateCrawlerRequest) (*v1.VPNGateCrawlerResponse, error) {
-	var servers []*VPNServer
-	response, err := http.Get("http://www.vpngate.net/api/iphone/")
+func (s *serviceServer) GetOpenVPNProfile(ctx context.Context, req *v1.GetOpenVPNProfileRequest) (*v1.GetOpenVPNProfileResponse, error) {
+	server, err := s.repo.FindVPNServerByID(ctx, req.Id)
+	if err != nil {
+		if err == ErrVPNServerNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, repositoryError(err)
+	}
+	// profiles of denied servers are not served
+	allowed, err := s.applyServerRules(ctx, []*VPNServer{server})
 	if err != nil {
 		return nil, err
-	} else {
-		defer response.Body.Close()
-		content, err := ioutil.ReadAll(response.Body)
+	}
+	if len(allowed) == 0 {
+		return nil, status.Error(codes.NotFound, ErrVPNServerNotFound.Error())
+	}
+	profile, err := BuildOpenVPNProfile(server.OpenVPNConfig, req.Directives, req.Dns)
+	if err != nil {
+		if errors.Is(err, ErrInvalidProfileOption) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.FailedPrecondition, "unusable openvpn config -> "+err.Error())
+	}
+	return &v1.GetOpenVPNProfileResponse{
+		Api:         apiVersion,
+		FileName:    fmt.Sprintf("%s_%s_%d.ovpn", server.HostName, server.Protocol, server.Port),
+		ContentType: openVPNProfileContentType,
+		Content:     profile,
+	}, nil
+}
+
+func (s *serviceServer) GetServerMetrics(ctx context.Context, req *v1.GetServerMetricsRequest) (*v1.GetServerMetricsResponse, error) {
+	to := time.Now()
+	if req.To != nil {
+		t, err := ptypes.Timestamp(req.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid to -> "+err.Error())
+		}
+		to = t
+	}
+	from := to.Add(-defaultMetricsRange)
+	if req.From != nil {
+		t, err := ptypes.Timestamp(req.From)
 		if err != nil {
-			return nil, err
+			return nil, status.Error(codes.InvalidArgument, "invalid from -> "+err.Error())
 		}
+		from = t
+	}
+	if !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	maxPoints := int(req.MaxPoints)
+	if maxPoints == 0 {
+		maxPoints = defaultMetricsPoints
+	}
+	if maxPoints > maxMetricsPoints {
+		maxPoints = maxMetricsPoints
+	}
 
-		csvString := strings.TrimLeft(string(content), "*vpn_servers\n")
+	if _, err := s.repo.FindVPNServerByID(ctx, req.Id); err != nil {
+		if err == ErrVPNServerNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, repositoryError(err)
+	}
+	metrics, err := s.repo.FindServerMetrics(ctx, req.Id, from, to)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	points, average := downsampleMetrics(metrics, from, to, maxPoints)
+	var resPoints []*v1.MetricPoint
+	for _, p := range points {
+		resPoints = append(resPoints, s.metricPointToResponse(p))
+	}
+	return &v1.GetServerMetricsResponse{
+		Api:     apiVersion,
+		Data:    resPoints,
+		Average: s.metricPointToResponse(average),
+	}, nil
+}
 
-		r := csv.NewReader(strings.NewReader(csvString))
-		i := 0
-		for {
-			record, err := r.Read()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				logger.Log.Warn("Wrong CSV record: "+err.Error())
-			} else {
-				if len(record) == 15 && i > 0 {
-					server := VPNServer{}
-					server.HostName = record[0]
-					server.IP = record[1]
-					if score, err := strconv.ParseInt(record[2], 10, 32); err == nil {
-						server.Score = int32(score)
-					}
-					if ping, err := strconv.ParseInt(record[3], 10, 16); err == nil {
-						server.Ping = int32(ping)
-					}
-					if speed, err := strconv.ParseInt(record[4], 10, 64); err == nil {
-						server.Speed = int64(speed)
-					}
-					country := Country{}
-					country.Name = record[5]
-					country.Code = record[6]
-					server.Country = country
-					if num, err := strconv.ParseInt(record[7], 10, 32); err == nil {
-						server.NumVPNSessions = int32(num)
-					}
-					if upTime, err := strconv.ParseInt(record[8], 10, 64); err == nil {
-						server.Uptime = int64(upTime)
-					}
-					if users, err := strconv.ParseInt(record[9], 10, 64); err == nil {
-						server.TotalUsers = int32(users)
-					}
-					if traffic, err := strconv.ParseInt(record[10], 10, 64); err == nil {
-						server.TotalTraffic = int64(traffic)
-					}
-					server.LogType = record[11]
-					server.Operator = record[12]
-					server.Message = record[13]
-					server.OpenVPNConfig = record[14]
-					servers = append(servers, &server)
-				}
-			}
-			i++
-		}
-	}
-	if err := s.repo.Truncate(); err == nil {
-		for _, srv := range servers {
-			countryID, err := s.repo.CreateCountry(srv.Country)
-			if err == nil {
-				srv.CountryID = int32(countryID)
-				_, err := s.repo.Create(*srv)
-				if err != nil {
-					logger.Log.Error(err.Error())
-				}
-			} else {
-				logger.Log.Error(err.Error())
-			}
+func (s *serviceServer) metricPointToResponse(p *MetricPoint) *v1.MetricPoint {
+	t, _ := ptypes.TimestampProto(p.Time)
+	return &v1.MetricPoint{
+		Time:           t,
+		Score:          p.Score,
+		Ping:           p.Ping,
+		Speed:          p.Speed,
+		NumVPNSessions: p.NumVPNSessions,
+		TotalUsers:     p.TotalUsers,
+		Samples:        int32(p.Samples),
+	}
+}
+
+func (s *serviceServer) ListCrawlRuns(ctx context.Context, req *v1.ListCrawlRunsRequest) (*v1.ListCrawlRunsResponse, error) {
+	limit := uint64(req.Limit)
+	if limit == 0 {
+		limit = defaultCrawlRunsLimit
+	}
+	runs, err := s.repo.FindCrawlRuns(ctx, req.Source, limit)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	var resRuns []*v1.CrawlRun
+	for _, r := range runs {
+		resRuns = append(resRuns, s.crawlRunEntityToResponse(r))
+	}
+	return &v1.ListCrawlRunsResponse{
+		Api:  apiVersion,
+		Data: resRuns,
+	}, nil
+}
+
+func (s *serviceServer) GetCrawlRun(ctx context.Context, req *v1.GetCrawlRunRequest) (*v1.GetCrawlRunResponse, error) {
+	run, err := s.repo.FindCrawlRunByID(ctx, req.Id)
+	if err != nil {
+		if err == ErrCrawlRunNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		return nil, repositoryError(err)
+	}
+	return &v1.GetCrawlRunResponse{
+		Api:  apiVersion,
+		Data: s.crawlRunEntityToResponse(run),
+	}, nil
+}
+
+func (s *serviceServer) CreateServerRule(ctx context.Context, req *v1.CreateServerRuleRequest) (*v1.CreateServerRuleResponse, error) {
+	rule := ServerRule{
+		Action:          req.Action,
+		IPCIDR:          req.IpCidr,
+		HostNamePattern: req.HostNamePattern,
+		OperatorPattern: req.OperatorPattern,
+		CountryCode:     req.CountryCode,
+		Comment:         req.Comment,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt, err := ptypes.Timestamp(req.ExpiresAt)
 		if err != nil {
-			logger.Log.Error(err.Error())
+			return nil, status.Error(codes.InvalidArgument, "invalid expiresAt -> "+err.Error())
 		}
+		rule.ExpiresAt = &expiresAt
 	}
-	var resVPNs []*v1.VPNServer
-	for _, v := range servers {
-		resVPNs = append(resVPNs, s.vpnEntityToResponse(v))
+	if err := validateServerRule(&rule, time.Now()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id, err := s.repo.CreateServerRule(ctx, rule)
+	if err != nil {
+		return nil, repositoryError(err)
 	}
+	s.rulesChanged(ctx)
+	created, err := s.repo.FindServerRuleByID(ctx, int32(id))
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	return &v1.CreateServerRuleResponse{
+		Api:  apiVersion,
+		Data: s.serverRuleEntityToResponse(created),
+	}, nil
+}
 
-	return &v1.VPNGateCrawlerResponse{
+func (s *serviceServer) ListServerRules(ctx context.Context, _ *v1.ListServerRulesRequest) (*v1.ListServerRulesResponse, error) {
+	rules, err := s.repo.FindServerRules(ctx)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	var resRules []*v1.ServerRule
+	for _, r := range rules {
+		resRules = append(resRules, s.serverRuleEntityToResponse(r))
+	}
+	return &v1.ListServerRulesResponse{
+		Api:  apiVersion,
+		Data: resRules,
+	}, nil
+}
+
+func (s *serviceServer) DeleteServerRule(ctx context.Context, req *v1.DeleteServerRuleRequest) (*v1.DeleteServerRuleResponse, error) {
+	if err := s.repo.DeleteServerRule(ctx, req.Id); err != nil {
+		if err == ErrServerRuleNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, repositoryError(err)
+	}
+	s.rulesChanged(ctx)
+	return &v1.DeleteServerRuleResponse{
 		Api: apiVersion,
-		Data: resVPNs,
 	}, nil
 }
 
+// repositoryError converts an unexpected repository error to a gRPC status, a query that ran out
+// of time is reported as DeadlineExceeded and one whose client went away as Canceled
+func repositoryError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "database deadline exceeded -> "+err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled -> "+err.Error())
+	}
+	return status.Error(codes.Unknown, "unknown error -> "+err.Error())
+}
+
+func (s *serviceServer) serverRuleEntityToResponse(r *ServerRule) *v1.ServerRule {
+	createdAt, _ := ptypes.TimestampProto(r.CreatedAt)
+	res := &v1.ServerRule{
+		Id:              r.ID,
+		Action:          r.Action,
+		IpCidr:          r.IPCIDR,
+		HostNamePattern: r.HostNamePattern,
+		OperatorPattern: r.OperatorPattern,
+		CountryCode:     r.CountryCode,
+		Comment:         r.Comment,
+		CreatedAt:       createdAt,
+	}
+	if r.ExpiresAt != nil {
+		res.ExpiresAt, _ = ptypes.TimestampProto(*r.ExpiresAt)
+	}
+	return res
+}
+
+func (s *serviceServer) crawlRunEntityToResponse(r *CrawlRun) *v1.CrawlRun {
+	startedAt, _ := ptypes.TimestampProto(r.StartedAt)
+	res := &v1.CrawlRun{
+		Id:           r.ID,
+		Source:       r.Source,
+		Status:       r.Status,
+		StartedAt:    startedAt,
+		RowsFetched:  r.RowsFetched,
+		RowsParsed:   r.RowsParsed,
+		RowsRejected: r.RowsRejected,
+		Inserted:     r.Inserted,
+		Updated:      r.Updated,
+		Removed:      r.Removed,
+		DbTimeMs:     r.DBTimeMs,
+		Error:        r.Error,
+	}
+	if r.FinishedAt != nil {
+		res.FinishedAt, _ = ptypes.TimestampProto(*r.FinishedAt)
+	}
+	return res
+}
+
 func (s *serviceServer) vpnEntityToResponse(v *VPNServer) *v1.VPNServer {
 	createdAt, _ := ptypes.TimestampProto(v.CreatedAt)
 	updatedAt, _ := ptypes.TimestampProto(v.UpdatedAt)
+	lastSeenAt, _ := ptypes.TimestampProto(v.LastSeenAt)
+	var probedAt *timestamp.Timestamp
+	if v.ProbedAt != nil {
+		probedAt, _ = ptypes.TimestampProto(*v.ProbedAt)
+	}
 	return &v1.VPNServer{
 		Id: v.ID,
 		HostName: v.HostName,
@@ -240,13 +720,59 @@ func (s *serviceServer) vpnEntityToResponse(v *VPNServer) *v1.VPNServer {
 		Operator: v.Operator,
 		Message: v.Message,
 		OpenVPNConfig: v.OpenVPNConfig,
+		Source: v.Source,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		LastSeenAt: lastSeenAt,
+		Protocol: v.Protocol,
+		Port: v.Port,
+		RemoteHosts: splitRemoteHosts(v.RemoteHosts),
+		Cipher: v.Cipher,
+		AuthDigest: v.AuthDigest,
+		Compression: v.Compression,
+		EmbedsCA: v.EmbedsCA,
+		EmbedsCert: v.EmbedsCert,
+		EmbedsKey: v.EmbedsKey,
+		Latitude: floatValue(v.Latitude),
+		Longitude: floatValue(v.Longitude),
+		Reachable: v.Reachable,
+		ProbeLatencyMs: v.ProbeLatencyMs,
+		ProbeFailures: v.ProbeFailures,
+		ProbedAt: probedAt,
+	}
+}
+
+
+// splitRemoteHosts splits the comma separated remote hosts column
+func splitRemoteHosts(remoteHosts string) []string {
+	if len(remoteHosts) == 0 {
+		return nil
+	}
+	return strings.Split(remoteHosts, ",")
+}
+
+// floatValue returns the value of f or 0 when it is nil
+func floatValue(f *float64) float64 {
+	if f == nil {
+		return 0
 	}
+	return *f
 }
 
+// NewServiceServer creates the v1 service backed by repo, crawling through crawler when elector
+// elects this replica, elector may be nil for a single replica and geo when no GeoIP database is configured
+func NewServiceServer(repo Repository, crawler *Crawler, elector *Elector, geo *geoip.DB, cfg Config) v1.ServiceServer {
+	return newServiceServer(repo, crawler, elector, geo, cfg)
+}
 
-func NewServiceServer(db *sqlx.DB) v1.ServiceServer {
-	repo := NewRepository(db)
-	return &serviceServer{repo}
+// newServiceServer creates the v1 service, its list snapshot is built by refreshSnapshot
+func newServiceServer(repo Repository, crawler *Crawler, elector *Elector, geo *geoip.DB, cfg Config) *serviceServer {
+	return &serviceServer{
+		repo:             repo,
+		crawler:          crawler,
+		elector:          elector,
+		maxProbeFailures: int32(cfg.ProbeMaxFailures),
+		ranker:           NewRanker(cfg.RankWeights),
+		geo:              geo,
+	}
 }
@@ -0,0 +1,199 @@
+package vpn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"squirrel-srv/pkg/api/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_serviceServer_ListVPNServers(t *testing.T) {
+	repo := NewMemoryRepository()
+	if err := seedMemoryRepository(context.Background(), repo, "testdata/fixture.json"); err != nil {
+		t.Fatal(err)
+	}
+	// a rule created since the last crawl, the denied server is still stored
+	if _, err := repo.CreateServerRule(context.Background(), ServerRule{Action: ruleActionDeny, HostNamePattern: "public-vpn-2"}); err != nil {
+		t.Fatal(err)
+	}
+	s := newServiceServer(repo, nil, nil, nil, Config{ProbeMaxFailures: 3})
+	tests := []struct {
+		name          string
+		req           *v1.ListVPNServerRequest
+		wantHosts     []string
+		wantTotal     int64
+		wantNextToken bool
+	}{
+		{
+			"Denied server should be left out of full pages and of the total",
+			&v1.ListVPNServerRequest{PageSize: 1},
+			[]string{"public-vpn-1"},
+			2,
+			true,
+		},
+		{
+			"Last page should have no next page token",
+			&v1.ListVPNServerRequest{PageSize: 2},
+			[]string{"public-vpn-1", "public-vpn-3"},
+			2,
+			false,
+		},
+		{
+			"Ranked list should hold at most a page of servers",
+			&v1.ListVPNServerRequest{Sort: sortByRank, PageSize: 1},
+			[]string{"public-vpn-1"},
+			2,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ListVPNServers(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var hosts []string
+			for _, srv := range res.Data {
+				hosts = append(hosts, srv.HostName)
+			}
+			if len(hosts) != len(tt.wantHosts) {
+				t.Fatalf("ListVPNServers() = %v, want %v", hosts, tt.wantHosts)
+			}
+			for i := range hosts {
+				if hosts[i] != tt.wantHosts[i] {
+					t.Errorf("ListVPNServers() = %v, want %v", hosts, tt.wantHosts)
+				}
+			}
+			if res.TotalCount != tt.wantTotal {
+				t.Errorf("ListVPNServers() total = %d, want %d", res.TotalCount, tt.wantTotal)
+			}
+			if (len(res.NextPageToken) > 0) != tt.wantNextToken {
+				t.Errorf("ListVPNServers() next page token = '%s', want one %v", res.NextPageToken, tt.wantNextToken)
+			}
+		})
+	}
+}
+
+func Test_serviceServer_VPNGateCrawler(t *testing.T) {
+	tests := []struct {
+		name string
+		// holder is the replica holding the lease, the replica runs without elector when it is empty
+		holder string
+		// busy runs the request while another crawl is in progress
+		busy       bool
+		wantCode   codes.Code
+		wantStored int64
+	}{
+		{"Leader should crawl", "leader", false, codes.OK, 2},
+		{"Replica without elector should crawl", "", false, codes.OK, 2},
+		{"Follower should not crawl", "follower", false, codes.Unavailable, 0},
+		{"Crawl in progress should not be overlapped", "", true, codes.Aborted, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepository()
+			src := &testSource{servers: testFeedServers("JP", 2)}
+			var elector *Elector
+			if len(tt.holder) > 0 {
+				if tt.holder == "follower" {
+					if _, err := repo.AcquireLease(context.Background(), schedulerLease, "leader", time.Hour); err != nil {
+						t.Fatal(err)
+					}
+				}
+				elector = NewElector(repo, schedulerLease, time.Hour)
+				elector.campaign(context.Background())
+			}
+			crawler := NewCrawler(repo, []Source{src}, nil, nil, Config{})
+			if tt.busy {
+				crawler.crawling = 1
+			}
+			s := newServiceServer(repo, crawler, elector, nil, Config{ProbeMaxFailures: 3})
+
+			_, err := s.VPNGateCrawler(context.Background(), &v1.VPNGateCrawlerRequest{})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("VPNGateCrawler() code = %v, want %v", got, tt.wantCode)
+			}
+			stored, err := repo.CountVPNServers(context.Background(), src.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("VPNGateCrawler() stored %d servers, want %d", stored, tt.wantStored)
+			}
+		})
+	}
+}
+
+func Test_serviceServer_DeleteServerRule(t *testing.T) {
+	tests := []struct {
+		name string
+		// afterCrawl creates the deny rule once the servers are crawled instead of before the crawl
+		afterCrawl bool
+	}{
+		{"Server denied at the crawl should come back once the rule is deleted", false},
+		{"Server denied since the crawl should come back once the rule is deleted", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepository()
+			src := &testSource{servers: testFeedServers("JP", 2)}
+			crawler := NewCrawler(repo, []Source{src}, nil, nil, Config{})
+			s := newServiceServer(repo, crawler, nil, nil, Config{ProbeMaxFailures: 3})
+			deny := &v1.CreateServerRuleRequest{Action: ruleActionDeny, HostNamePattern: "JP-0"}
+
+			var rule *v1.CreateServerRuleResponse
+			var err error
+			if !tt.afterCrawl {
+				if rule, err = s.CreateServerRule(context.Background(), deny); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := s.VPNGateCrawler(context.Background(), &v1.VPNGateCrawlerRequest{}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.afterCrawl {
+				if rule, err = s.CreateServerRule(context.Background(), deny); err != nil {
+					t.Fatal(err)
+				}
+			}
+			stored, err := repo.CountVPNServers(context.Background(), src.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored != 2 {
+				t.Errorf("denied server should be kept stored, stored %d servers, want 2", stored)
+			}
+			if got := waitListedVPNServers(t, s, 1); got != 1 {
+				t.Errorf("ListVPNServers() = %d servers with the rule, want 1", got)
+			}
+
+			// the feed is not crawled again, the server comes back from the stored servers
+			if _, err := s.DeleteServerRule(context.Background(), &v1.DeleteServerRuleRequest{Id: rule.Data.Id}); err != nil {
+				t.Fatal(err)
+			}
+			if got := waitListedVPNServers(t, s, 2); got != 2 {
+				t.Errorf("ListVPNServers() = %d servers once the rule is deleted, want 2", got)
+			}
+		})
+	}
+}
+
+// waitListedVPNServers waits until s lists want servers, the snapshots are built in the background,
+// and returns the number of servers listed last
+func waitListedVPNServers(t *testing.T, s *serviceServer, want int) int {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := s.ListVPNServers(context.Background(), &v1.ListVPNServerRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Data) == want || time.Now().After(deadline) {
+			return len(res.Data)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,251 @@
+package vpn
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"squirrel-srv/pkg/api/v1"
+	"squirrel-srv/pkg/logger"
+)
+
+const (
+	// rulesCheckInterval is how often the server rules are compared to the ones of the snapshot, a rule
+	// change made on another replica stops the snapshot from being served within it
+	rulesCheckInterval = time.Second
+)
+
+// responseSnapshot holds the list responses of the data at the time it was built, it is never modified
+// once published so its responses are shared by every request
+type responseSnapshot struct {
+	countries *v1.ListCountriesResponse
+	// servers maps a country code to the first page of its VPN servers in the default order,
+	// the empty code to the one of every country
+	servers map[string]*v1.ListVPNServerResponse
+	// expiresAt is when the first server rule expires, the responses may then list denied servers
+	expiresAt *time.Time
+	// rulesVersion is the version of the server rules the responses were built with
+	rulesVersion ServerRulesVersion
+	// rules are the server rules that were active when the responses were built
+	rules activeRules
+}
+
+// activeRules are the ids of the unexpired allow and deny rules
+type activeRules struct {
+	allow map[int32]bool
+	deny  map[int32]bool
+}
+
+// newActiveRules returns the rules of rules that are not expired at now
+func newActiveRules(rules []*ServerRule, now time.Time) activeRules {
+	active := activeRules{allow: make(map[int32]bool), deny: make(map[int32]bool)}
+	for _, rule := range rules {
+		if rule.ExpiresAt != nil && !rule.ExpiresAt.After(now) {
+			continue
+		}
+		if rule.Action == ruleActionAllow {
+			active.allow[rule.ID] = true
+		} else {
+			active.deny[rule.ID] = true
+		}
+	}
+	return active
+}
+
+// hides reports whether replacing the rules a with b may hide servers, that is when b has a deny
+// rule a has not or lacks an allow rule of a
+func (a activeRules) hides(b activeRules) bool {
+	for id := range b.deny {
+		if !a.deny[id] {
+			return true
+		}
+	}
+	for id := range a.allow {
+		if !b.allow[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// valid reports whether the responses of snap may still be served
+func (snap *responseSnapshot) valid(now time.Time) bool {
+	return snap != nil && (snap.expiresAt == nil || now.Before(*snap.expiresAt))
+}
+
+// snapshotKey returns the key of the precomputed response of req, ok is false when the request
+// has filters, a sort order or a page other than the defaults
+func snapshotKey(req *v1.ListVPNServerRequest) (key string, ok bool) {
+	if req.IncludeUnreachable || len(req.PageToken) > 0 ||
+		req.MinSpeed > 0 || req.MaxPing > 0 || req.MaxSessions > 0 || len(req.Protocol) > 0 || len(req.Operator) > 0 {
+		return "", false
+	}
+	if (len(req.Sort) > 0 && req.Sort != sortBySpeed) || (len(req.SortBy) > 0 && req.SortBy != sortBySpeed) {
+		return "", false
+	}
+	if (len(req.Order) > 0 && req.Order != defaultOrder(sortBySpeed)) ||
+		(req.PageSize != 0 && req.PageSize != defaultPageSize) {
+		return "", false
+	}
+	return req.CountryCode, true
+}
+
+// currentSnapshot returns the published snapshot, nil before the first one is built or after an invalidation
+func (s *serviceServer) currentSnapshot() *responseSnapshot {
+	snap, _ := s.snapshot.Load().(*responseSnapshot)
+	return snap
+}
+
+// servedSnapshot returns the snapshot requests are answered from, nil when they are read from the
+// repository. The server rules version is checked at most once per rulesCheckInterval and concurrent
+// checks share a single query.
+func (s *serviceServer) servedSnapshot() *responseSnapshot {
+	snap := s.currentSnapshot()
+	if snap == nil {
+		return nil
+	}
+	if snap.valid(time.Now()) && time.Since(time.Unix(0, atomic.LoadInt64(&s.rulesCheckedAt))) < rulesCheckInterval {
+		return snap
+	}
+	_, err, _ := s.rulesCheck.Do("rules", func() (interface{}, error) {
+		// the check is shared, a caller that goes away must not fail it for the others
+		return nil, s.checkSnapshotRules(context.Background())
+	})
+	if err != nil {
+		return nil
+	}
+	snap = s.currentSnapshot()
+	if !snap.valid(time.Now()) {
+		return nil
+	}
+	return snap
+}
+
+// checkSnapshotRules compares the server rules to the ones of the published snapshot. A snapshot
+// built before rules that may hide servers stops being served at once, other rule changes are
+// picked up by a new snapshot swapped in once built.
+func (s *serviceServer) checkSnapshotRules(ctx context.Context) error {
+	snap := s.currentSnapshot()
+	if snap == nil {
+		return nil
+	}
+	now := time.Now()
+	if !snap.valid(now) {
+		s.rebuildSnapshot()
+		return nil
+	}
+	version, err := s.repo.FindServerRulesVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version != snap.rulesVersion {
+		rules, err := s.repo.FindServerRules(ctx)
+		if err != nil {
+			return err
+		}
+		if snap.rules.hides(newActiveRules(rules, now)) {
+			s.rebuildSnapshot()
+			return nil
+		}
+		s.updateSnapshot()
+	}
+	atomic.StoreInt64(&s.rulesCheckedAt, now.UnixNano())
+	return nil
+}
+
+// rulesChanged updates the snapshot after a server rule change of this replica, it stops being
+// served when the rules could not be compared
+func (s *serviceServer) rulesChanged(ctx context.Context) {
+	if err := s.checkSnapshotRules(ctx); err != nil {
+		logger.Log.Warn("check response snapshot rules error: " + err.Error())
+		s.rebuildSnapshot()
+	}
+}
+
+// refreshSnapshot builds the list responses from the repository and publishes them,
+// a snapshot invalidated while it was built is dropped
+func (s *serviceServer) refreshSnapshot(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	s.snapshotMu.Lock()
+	generation := s.snapshotGen
+	s.snapshotMu.Unlock()
+
+	// rules changed while the snapshot is built make it stale at the first check
+	rulesVersion, err := s.repo.FindServerRulesVersion(ctx)
+	if err != nil {
+		return err
+	}
+	rules, err := s.repo.FindServerRules(ctx)
+	if err != nil {
+		return err
+	}
+	countries, err := s.listCountries(ctx)
+	if err != nil {
+		return err
+	}
+	snap := &responseSnapshot{
+		countries:    countries,
+		servers:      make(map[string]*v1.ListVPNServerResponse, len(countries.Data)+1),
+		rulesVersion: rulesVersion,
+	}
+	now := time.Now()
+	snap.rules = newActiveRules(rules, now)
+	for _, rule := range rules {
+		if rule.ExpiresAt != nil && rule.ExpiresAt.After(now) && (snap.expiresAt == nil || rule.ExpiresAt.Before(*snap.expiresAt)) {
+			snap.expiresAt = rule.ExpiresAt
+		}
+	}
+	codes := []string{""}
+	for _, c := range countries.Data {
+		codes = append(codes, c.Code)
+	}
+	for _, code := range codes {
+		res, err := s.listVPNServers(ctx, &v1.ListVPNServerRequest{CountryCode: code})
+		if err != nil {
+			return err
+		}
+		snap.servers[code] = res
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	if generation == s.snapshotGen {
+		s.snapshot.Store(snap)
+		atomic.StoreInt64(&s.rulesCheckedAt, now.UnixNano())
+	}
+	return nil
+}
+
+// invalidateSnapshot stops serving the published snapshot until the next refresh
+func (s *serviceServer) invalidateSnapshot() {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	s.snapshotGen++
+	s.snapshot.Store((*responseSnapshot)(nil))
+}
+
+// rebuildSnapshot invalidates the published snapshot and builds a new one in the background,
+// the lists are read from the repository meanwhile
+func (s *serviceServer) rebuildSnapshot() {
+	s.invalidateSnapshot()
+	go func() {
+		if err := s.refreshSnapshot(context.Background()); err != nil {
+			logger.Log.Warn("rebuild response snapshot error: " + err.Error())
+		}
+	}()
+}
+
+// updateSnapshot builds a new snapshot in the background and swaps it for the published one, which
+// is served until then. Updates requested while one is pending are merged into it.
+func (s *serviceServer) updateSnapshot() {
+	if !atomic.CompareAndSwapInt32(&s.snapshotUpdating, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&s.snapshotUpdating, 0)
+		if err := s.refreshSnapshot(context.Background()); err != nil {
+			logger.Log.Warn("update response snapshot error: " + err.Error())
+		}
+	}()
+}
@@ -0,0 +1,225 @@
+package vpn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"squirrel-srv/pkg/api/v1"
+)
+
+func Test_serviceServer_snapshot(t *testing.T) {
+	repo := NewMemoryRepository()
+	if err := seedMemoryRepository(context.Background(), repo, "testdata/fixture.json"); err != nil {
+		t.Fatal(err)
+	}
+	s := newServiceServer(repo, nil, nil, nil, Config{ProbeMaxFailures: 3})
+	if s.currentSnapshot() != nil {
+		t.Fatal("currentSnapshot() should be nil before the first refresh")
+	}
+	if err := s.refreshSnapshot(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	snap := s.currentSnapshot()
+
+	tests := []struct {
+		name         string
+		req          *v1.ListVPNServerRequest
+		wantSnapshot bool
+		wantTotal    int64
+		wantErr      bool
+	}{
+		{"Plain list should be served from the snapshot", &v1.ListVPNServerRequest{}, true, 3, false},
+		{"Plain list of a country should be served from the snapshot", &v1.ListVPNServerRequest{CountryCode: "JP"}, true, 2, false},
+		{"Default sort order should be served from the snapshot", &v1.ListVPNServerRequest{SortBy: sortBySpeed, Order: orderDesc, PageSize: defaultPageSize}, true, 3, false},
+		{"Filtered list should be read from the repository", &v1.ListVPNServerRequest{Protocol: "udp"}, false, 1, false},
+		{"Other sort order should be read from the repository", &v1.ListVPNServerRequest{SortBy: sortByPing}, false, 3, false},
+		{"Unknown country should be read from the repository", &v1.ListVPNServerRequest{CountryCode: "FR"}, false, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ListVPNServers(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListVPNServers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := res == snap.servers[tt.req.CountryCode]; got != tt.wantSnapshot {
+				t.Errorf("ListVPNServers() served from the snapshot = %v, want %v", got, tt.wantSnapshot)
+			}
+			if res.TotalCount != tt.wantTotal {
+				t.Errorf("ListVPNServers() total = %d, want %d", res.TotalCount, tt.wantTotal)
+			}
+		})
+	}
+
+	countries, err := s.ListCountries(context.Background(), &v1.ListCountriesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countries != snap.countries || len(countries.Data) != 2 {
+		t.Errorf("ListCountries() = %v, want the %d countries of the snapshot", countries, len(snap.countries.Data))
+	}
+
+	s.invalidateSnapshot()
+	res, err := s.ListVPNServers(context.Background(), &v1.ListVPNServerRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == snap.servers[""] || s.currentSnapshot() != nil {
+		t.Error("ListVPNServers() should be read from the repository after an invalidation")
+	}
+}
+
+func Test_serviceServer_snapshotRules(t *testing.T) {
+	repo := NewMemoryRepository()
+	if err := seedMemoryRepository(context.Background(), repo, "testdata/fixture.json"); err != nil {
+		t.Fatal(err)
+	}
+	s := newServiceServer(repo, nil, nil, nil, Config{ProbeMaxFailures: 3})
+	if err := s.refreshSnapshot(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	snap := s.currentSnapshot()
+
+	// a rule created through another replica is only seen in the repository
+	if _, err := repo.CreateServerRule(context.Background(), ServerRule{Action: ruleActionDeny, CountryCode: "JP"}); err != nil {
+		t.Fatal(err)
+	}
+	s.rulesCheckedAt = 0
+	res, err := s.ListVPNServers(context.Background(), &v1.ListVPNServerRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == snap.servers[""] || len(res.Data) != 1 {
+		t.Errorf("ListVPNServers() = %d servers, want the server of KR read from the repository", len(res.Data))
+	}
+
+	// the stale snapshot is rebuilt in the background
+	deadline := time.Now().Add(5 * time.Second)
+	for s.currentSnapshot() == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	rebuilt := s.currentSnapshot()
+	if rebuilt == nil {
+		t.Fatal("currentSnapshot() should be rebuilt after a rule change")
+	}
+	res, err = s.ListVPNServers(context.Background(), &v1.ListVPNServerRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != rebuilt.servers[""] || len(res.Data) != 1 {
+		t.Errorf("ListVPNServers() = %d servers, want the server of KR served from the rebuilt snapshot", len(res.Data))
+	}
+}
+
+func Test_activeRules_hides(t *testing.T) {
+	rules := func(allow, deny []int32) activeRules {
+		active := activeRules{allow: make(map[int32]bool), deny: make(map[int32]bool)}
+		for _, id := range allow {
+			active.allow[id] = true
+		}
+		for _, id := range deny {
+			active.deny[id] = true
+		}
+		return active
+	}
+	tests := []struct {
+		name string
+		from activeRules
+		to   activeRules
+		want bool
+	}{
+		{"Same rules should not hide", rules([]int32{1}, []int32{2}), rules([]int32{1}, []int32{2}), false},
+		{"New deny rule should hide", rules(nil, []int32{2}), rules(nil, []int32{2, 3}), true},
+		{"Removed allow rule should hide", rules([]int32{1}, []int32{2}), rules(nil, []int32{2}), true},
+		{"Removed deny rule should not hide", rules(nil, []int32{2, 3}), rules(nil, []int32{2}), false},
+		{"New allow rule should not hide", rules(nil, []int32{2}), rules([]int32{1}, []int32{2}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.hides(tt.to); got != tt.want {
+				t.Errorf("hides() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serviceServer_rulesChanged(t *testing.T) {
+	tests := []struct {
+		name string
+		// change changes the rules of repo, deny is the id of the deny rule of the fixture
+		change func(t *testing.T, repo Repository, deny int64)
+		// wantServed is whether the snapshot of the previous rules is still served until a new one is built
+		wantServed bool
+	}{
+		{
+			"New deny rule should stop the snapshot at once",
+			func(t *testing.T, repo Repository, _ int64) {
+				if _, err := repo.CreateServerRule(context.Background(), ServerRule{Action: ruleActionDeny, CountryCode: "KR"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			false,
+		},
+		{
+			"New allow rule should keep the snapshot until the new one is built",
+			func(t *testing.T, repo Repository, _ int64) {
+				if _, err := repo.CreateServerRule(context.Background(), ServerRule{Action: ruleActionAllow, CountryCode: "JP"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			true,
+		},
+		{
+			"Deleted deny rule should keep the snapshot until the new one is built",
+			func(t *testing.T, repo Repository, deny int64) {
+				if err := repo.DeleteServerRule(context.Background(), int32(deny)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepository()
+			if err := seedMemoryRepository(context.Background(), repo, "testdata/fixture.json"); err != nil {
+				t.Fatal(err)
+			}
+			deny, err := repo.CreateServerRule(context.Background(), ServerRule{Action: ruleActionDeny, HostNamePattern: "public-vpn-2"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := newServiceServer(repo, nil, nil, nil, Config{ProbeMaxFailures: 3})
+			if err := s.refreshSnapshot(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			snap := s.currentSnapshot()
+
+			// no snapshot is built before the one served after the change is checked
+			s.refreshMu.Lock()
+			tt.change(t, repo, deny)
+			s.rulesChanged(context.Background())
+			got := s.currentSnapshot() == snap
+			s.refreshMu.Unlock()
+			if got != tt.wantServed {
+				t.Errorf("rulesChanged() kept the snapshot = %v, want %v", got, tt.wantServed)
+			}
+
+			// either way a snapshot of the new rules is published
+			deadline := time.Now().Add(5 * time.Second)
+			for (s.currentSnapshot() == nil || s.currentSnapshot() == snap) && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			version, err := repo.FindServerRulesVersion(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rebuilt := s.currentSnapshot(); rebuilt == nil || rebuilt.rulesVersion != version {
+				t.Error("currentSnapshot() should be built with the new rules")
+			}
+		})
+	}
+}
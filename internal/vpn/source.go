@@ -0,0 +1,152 @@
+package vpn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Source is a provider of VPN servers the crawler pulls from
+type Source interface {
+	// Name identifies the source, it is recorded on every crawled server
+	Name() string
+	// Fetch downloads the raw feed of the source
+	Fetch(ctx context.Context) (*Feed, error)
+	// Parse converts a raw feed into VPN servers
+	Parse(content []byte) (*ParseResult, error)
+}
+
+// Feed is a raw feed downloaded by a source
+type Feed struct {
+	Content []byte
+	// URL is where the feed was downloaded from
+	URL string
+	// Header is the HTTP header of the response, it is nil for feeds not downloaded over HTTP
+	Header http.Header
+}
+
+// CommitSource is a Source fetching its feed conditionally. Fetch returns fetch.ErrNotModified
+// when the feed did not change since the last commit, the crawler commits once a feed is persisted.
+type CommitSource interface {
+	Source
+	// Commit marks the last fetched feed as persisted
+	Commit()
+}
+
+// ParseResult is the outcome of parsing a raw feed
+type ParseResult struct {
+	// Servers are the records parsed successfully
+	Servers []*VPNServer
+	// Rows is number of records read from the feed
+	Rows int
+	// Rejected are the records that could not be parsed
+	Rejected []RejectedRow
+	// Truncated reports the feed misses its end marker, records may be missing
+	Truncated bool
+}
+
+// RejectedRow is a record of a raw feed that was not crawled
+type RejectedRow struct {
+	// Line is line number of the record in the feed, 0 when it is unknown
+	Line     int
+	HostName string
+	IP       string
+	// Reason tells why the record was rejected
+	Reason string
+}
+
+// serverFieldNames are the names a feed format gives to the fields of a server, validation errors use them
+type serverFieldNames struct {
+	HostName      string
+	IP            string
+	CountryCode   string
+	OpenVPNConfig string
+}
+
+// validateVPNServer checks a server parsed from a feed has the fields every crawled server needs
+func validateVPNServer(server *VPNServer, names serverFieldNames) error {
+	if len(server.HostName) == 0 {
+		return errors.New("empty " + names.HostName)
+	}
+	if net.ParseIP(server.IP) == nil {
+		return fmt.Errorf("invalid %s '%s'", names.IP, server.IP)
+	}
+	if !isCountryCode(server.Country.Code) {
+		return fmt.Errorf("invalid %s '%s'", names.CountryCode, server.Country.Code)
+	}
+	if len(server.OpenVPNConfig) == 0 {
+		return errors.New("empty " + names.OpenVPNConfig)
+	}
+	return nil
+}
+
+// SourceFactory builds a Source from the server configuration
+type SourceFactory func(cfg Config) (Source, error)
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]SourceFactory)
+)
+
+// RegisterSource makes a source available by name for the crawler.
+// It panics if a source with the same name is already registered.
+func RegisterSource(name string, factory SourceFactory) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	if factory == nil {
+		panic("vpn: RegisterSource factory is nil")
+	}
+	if _, dup := sources[name]; dup {
+		panic("vpn: RegisterSource called twice for source " + name)
+	}
+	sources[name] = factory
+}
+
+// Sources returns a sorted list of the names of the registered sources
+func Sources() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	var names []string
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSources builds the sources enabled in cfg.CrawlerSources
+func NewSources(cfg Config) ([]Source, error) {
+	var enabled []Source
+	for _, name := range strings.Split(cfg.CrawlerSources, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		src, err := newSource(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		enabled = append(enabled, src)
+	}
+	return enabled, nil
+}
+
+// newSource builds the source registered as name
+func newSource(name string, cfg Config) (Source, error) {
+	sourcesMu.RLock()
+	factory, ok := sources[name]
+	sourcesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown crawler source '%s'", name)
+	}
+	src, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crawler source '%s': %v", name, err)
+	}
+	return src, nil
+}
@@ -0,0 +1,155 @@
+package vpn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// fileSourceName is name of the local file source
+	fileSourceName = "file"
+)
+
+func init() {
+	RegisterSource(fileSourceName, func(cfg Config) (Source, error) {
+		if len(cfg.CrawlerFilePath) == 0 {
+			return nil, errors.New("crawler file path is not provided")
+		}
+		return &fileSource{path: cfg.CrawlerFilePath}, nil
+	})
+}
+
+// fileSource reads VPN servers from a local JSON or VPNGate formatted CSV file
+type fileSource struct {
+	path string
+}
+
+// fileServer is JSON representation of a server in a local file
+type fileServer struct {
+	HostName       string `json:"hostName"`
+	IP             string `json:"ip"`
+	Score          int32  `json:"score"`
+	Ping           int32  `json:"ping"`
+	Speed          int64  `json:"speed"`
+	CountryName    string `json:"countryName"`
+	CountryCode    string `json:"countryCode"`
+	NumVPNSessions int32  `json:"numVPNSessions"`
+	Uptime         int64  `json:"uptime"`
+	TotalUsers     int32  `json:"totalUsers"`
+	TotalTraffic   int64  `json:"totalTraffic"`
+	LogType        string `json:"logType"`
+	Operator       string `json:"operator"`
+	Message        string `json:"message"`
+	OpenVPNConfig  string `json:"openVPNConfig"`
+}
+
+func (s *fileSource) Name() string {
+	return fileSourceName
+}
+
+func (s *fileSource) Fetch(_ context.Context) (*Feed, error) {
+	content, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return &Feed{
+		Content: content,
+		URL:     "file://" + s.path,
+	}, nil
+}
+
+func (s *fileSource) Parse(content []byte) (*ParseResult, error) {
+	if strings.ToLower(filepath.Ext(s.path)) != ".json" {
+		return parseVPNGateCSV(content)
+	}
+	var records []fileServer
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, err
+	}
+	result := &ParseResult{
+		Servers: make([]*VPNServer, 0, len(records)),
+		Rows:    len(records),
+	}
+	// records are numbered from 1 in rejection reasons, JSON records have no line
+	seen := make(map[string]int)
+	for i, r := range records {
+		server := &VPNServer{
+			HostName:       strings.TrimSpace(r.HostName),
+			IP:             strings.TrimSpace(r.IP),
+			Score:          r.Score,
+			Ping:           r.Ping,
+			Speed:          r.Speed,
+			NumVPNSessions: r.NumVPNSessions,
+			Uptime:         r.Uptime,
+			TotalUsers:     r.TotalUsers,
+			TotalTraffic:   r.TotalTraffic,
+			LogType:        r.LogType,
+			Operator:       r.Operator,
+			Message:        r.Message,
+			OpenVPNConfig:  r.OpenVPNConfig,
+			Country: Country{
+				Name: strings.TrimSpace(r.CountryName),
+				Code: strings.ToUpper(strings.TrimSpace(r.CountryCode)),
+			},
+		}
+		if err := validateFileServer(server); err != nil {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				HostName: r.HostName,
+				IP:       r.IP,
+				Reason:   "record " + strconv.Itoa(i+1) + ": " + err.Error(),
+			})
+			continue
+		}
+		key := server.IP + "/" + server.HostName
+		if dup, ok := seen[key]; ok {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				HostName: server.HostName,
+				IP:       server.IP,
+				Reason:   "record " + strconv.Itoa(i+1) + ": duplicate of record " + strconv.Itoa(dup),
+			})
+			continue
+		}
+		seen[key] = i + 1
+		result.Servers = append(result.Servers, server)
+	}
+	return result, nil
+}
+
+// fileServerFields are the names of the validated fields in the JSON records
+var fileServerFields = serverFieldNames{
+	HostName:      "hostName",
+	IP:            "ip",
+	CountryCode:   "countryCode",
+	OpenVPNConfig: "openVPNConfig",
+}
+
+// validateFileServer validates a server of a JSON record like the VPNGate CSV records are validated
+func validateFileServer(server *VPNServer) error {
+	if err := validateVPNServer(server, fileServerFields); err != nil {
+		return err
+	}
+	numbers := []struct {
+		name  string
+		value int64
+	}{
+		{"score", int64(server.Score)},
+		{"ping", int64(server.Ping)},
+		{"speed", server.Speed},
+		{"numVPNSessions", int64(server.NumVPNSessions)},
+		{"uptime", server.Uptime},
+		{"totalUsers", int64(server.TotalUsers)},
+		{"totalTraffic", server.TotalTraffic},
+	}
+	for _, n := range numbers {
+		if n.value < 0 {
+			return fmt.Errorf("invalid %s '%d'", n.name, n.value)
+		}
+	}
+	return nil
+}
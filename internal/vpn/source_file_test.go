@@ -0,0 +1,88 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_fileSource_Parse(t *testing.T) {
+	const record = `{"hostName": "public-vpn-1", "ip": "1.2.3.4", "speed": 3000, "countryName": "Japan", "countryCode": "jp", "openVPNConfig": "Y29uZmln"}`
+	tests := []struct {
+		name         string
+		content      string
+		wantServers  []*VPNServer
+		wantRows     int
+		wantRejected []RejectedRow
+		wantErr      bool
+	}{
+		{
+			"Valid record should be parsed",
+			`[` + record + `]`,
+			[]*VPNServer{
+				{HostName: "public-vpn-1", IP: "1.2.3.4", Speed: 3000, OpenVPNConfig: "Y29uZmln", Country: Country{Name: "Japan", Code: "JP"}},
+			},
+			1,
+			nil,
+			false,
+		},
+		{
+			"Duplicate record should be rejected",
+			`[` + record + `,` + record + `]`,
+			[]*VPNServer{
+				{HostName: "public-vpn-1", IP: "1.2.3.4", Speed: 3000, OpenVPNConfig: "Y29uZmln", Country: Country{Name: "Japan", Code: "JP"}},
+			},
+			2,
+			[]RejectedRow{{HostName: "public-vpn-1", IP: "1.2.3.4", Reason: "record 2: duplicate of record 1"}},
+			false,
+		},
+		{
+			"Invalid records should be rejected",
+			`[
+				{"hostName": "public-vpn-2", "ip": "1.2.3", "countryCode": "JP", "openVPNConfig": "Y29uZmln"},
+				{"hostName": "public-vpn-3", "ip": "1.2.3.5", "countryCode": "", "openVPNConfig": "Y29uZmln"},
+				{"hostName": "", "ip": "1.2.3.6", "countryCode": "JP", "openVPNConfig": "Y29uZmln"},
+				{"hostName": "public-vpn-4", "ip": "1.2.3.7", "countryCode": "JP"},
+				{"hostName": "public-vpn-5", "ip": "1.2.3.8", "countryCode": "JP", "openVPNConfig": "Y29uZmln", "ping": -1}
+			]`,
+			[]*VPNServer{},
+			5,
+			[]RejectedRow{
+				{HostName: "public-vpn-2", IP: "1.2.3", Reason: "record 1: invalid ip '1.2.3'"},
+				{HostName: "public-vpn-3", IP: "1.2.3.5", Reason: "record 2: invalid countryCode ''"},
+				{HostName: "", IP: "1.2.3.6", Reason: "record 3: empty hostName"},
+				{HostName: "public-vpn-4", IP: "1.2.3.7", Reason: "record 4: empty openVPNConfig"},
+				{HostName: "public-vpn-5", IP: "1.2.3.8", Reason: "record 5: invalid ping '-1'"},
+			},
+			false,
+		},
+		{
+			"Malformed JSON should be error",
+			`[` + record,
+			nil,
+			0,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fileSource{path: "servers.json"}
+			got, err := s.Parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Servers, tt.wantServers) {
+				t.Errorf("Parse() servers = %+v, want %+v", got.Servers, tt.wantServers)
+			}
+			if got.Rows != tt.wantRows {
+				t.Errorf("Parse() rows = %d, want %d", got.Rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(got.Rejected, tt.wantRejected) {
+				t.Errorf("Parse() rejected = %+v, want %+v", got.Rejected, tt.wantRejected)
+			}
+		})
+	}
+}
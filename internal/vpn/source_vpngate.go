@@ -0,0 +1,272 @@
+package vpn
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"squirrel-srv/pkg/fetch"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// vpnGateSourceName is name of the VPNGate source
+	vpnGateSourceName = "vpngate"
+	// vpnGateAPIURL is the public VPNGate server list, it is the default of the comma separated
+	// CrawlerVPNGateURLs
+	vpnGateAPIURL = "http://www.vpngate.net/api/iphone/"
+)
+
+func init() {
+	RegisterSource(vpnGateSourceName, func(cfg Config) (Source, error) {
+		var urls []string
+		for _, u := range strings.Split(cfg.CrawlerVPNGateURLs, ",") {
+			if u = strings.TrimSpace(u); len(u) > 0 {
+				urls = append(urls, u)
+			}
+		}
+		if len(urls) == 0 {
+			return nil, errors.New("VPNGate URLs are not provided")
+		}
+		client, err := fetch.NewClient(cfg.fetchConfig())
+		if err != nil {
+			return nil, err
+		}
+		return &vpnGateSource{urls: urls, client: client}, nil
+	})
+}
+
+// vpnGateSource crawls the VPNGate academic project server list from its mirrors
+type vpnGateSource struct {
+	urls   []string
+	client *fetch.Client
+
+	mu sync.Mutex
+	// fetched is the last fetched response, it is remembered by Commit
+	fetched *fetch.Response
+}
+
+func (s *vpnGateSource) Name() string {
+	return vpnGateSourceName
+}
+
+func (s *vpnGateSource) Fetch(ctx context.Context) (*Feed, error) {
+	res, err := s.client.Fetch(ctx, s.urls)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.fetched = res
+	s.mu.Unlock()
+	return &Feed{
+		Content: res.Body,
+		URL:     res.URL,
+		Header:  res.Header,
+	}, nil
+}
+
+func (s *vpnGateSource) Commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client.Remember(s.fetched)
+	s.fetched = nil
+}
+
+func (s *vpnGateSource) Parse(content []byte) (*ParseResult, error) {
+	return parseVPNGateCSV(content)
+}
+
+// vpnGateRequiredColumns are the columns a VPNGate CSV header must have,
+// the numeric and descriptive columns are optional
+var vpnGateRequiredColumns = []string{
+	"HostName",
+	"IP",
+	"CountryShort",
+	"OpenVPN_ConfigData_Base64",
+}
+
+// parseVPNGateCSV parses VPNGate CSV format, it is shared by sources serving the same format.
+// The feed starts with a *vpn_servers line and a #HostName,IP,... header that maps columns by name,
+// it ends with a * trailer. Records failing validation are reported as rejected rows.
+func parseVPNGateCSV(content []byte) (*ParseResult, error) {
+	result := &ParseResult{}
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var columns map[string]int
+	var header []string
+	seen := make(map[string]int)
+	trailer := false
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			if columns == nil {
+				return nil, fmt.Errorf("invalid VPNGate CSV: %v", err)
+			}
+			result.Rows++
+			result.Rejected = append(result.Rejected, RejectedRow{Line: line, Reason: err.Error()})
+			continue
+		}
+		first := strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+		if columns == nil {
+			// everything before the header is ignored, that is the *vpn_servers line
+			if !strings.HasPrefix(first, "#") {
+				continue
+			}
+			header = record
+			header[0] = strings.TrimPrefix(first, "#")
+			columns = make(map[string]int, len(header))
+			for i, name := range header {
+				columns[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			for _, name := range vpnGateRequiredColumns {
+				if _, ok := columns[strings.ToLower(name)]; !ok {
+					return nil, fmt.Errorf("invalid VPNGate CSV: header has no %s column", name)
+				}
+			}
+			continue
+		}
+		if len(record) == 1 && strings.HasPrefix(first, "*") {
+			trailer = true
+			break
+		}
+		result.Rows++
+		server, err := parseVPNGateRecord(record, len(header), columns)
+		if err != nil {
+			rejected := RejectedRow{Line: line, Reason: err.Error()}
+			if i, ok := columns["hostname"]; ok && i < len(record) {
+				rejected.HostName = record[i]
+			}
+			if i, ok := columns["ip"]; ok && i < len(record) {
+				rejected.IP = record[i]
+			}
+			result.Rejected = append(result.Rejected, rejected)
+			continue
+		}
+		key := server.IP + "/" + server.HostName
+		if dup, ok := seen[key]; ok {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				Line:     line,
+				HostName: server.HostName,
+				IP:       server.IP,
+				Reason:   "duplicate of line " + strconv.Itoa(dup),
+			})
+			continue
+		}
+		seen[key] = line
+		result.Servers = append(result.Servers, server)
+	}
+	if columns == nil {
+		return nil, errors.New("invalid VPNGate CSV: header is missing")
+	}
+	result.Truncated = !trailer
+	return result, nil
+}
+
+// parseVPNGateRecord validates a record and converts it into a VPN server
+func parseVPNGateRecord(record []string, fields int, columns map[string]int) (*VPNServer, error) {
+	if len(record) != fields {
+		return nil, fmt.Errorf("expected %d fields, got %d", fields, len(record))
+	}
+	value := func(name string) string {
+		if i, ok := columns[strings.ToLower(name)]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	// numeric fields are 0 when the column is missing, empty or - as VPNGate writes unmeasured values
+	number := func(name string, bits int) (int64, error) {
+		v := value(name)
+		if len(v) == 0 || v == "-" {
+			return 0, nil
+		}
+		n, err := strconv.ParseInt(v, 10, bits)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid %s '%s'", name, v)
+		}
+		return n, nil
+	}
+
+	server := &VPNServer{
+		HostName:      value("HostName"),
+		IP:            value("IP"),
+		LogType:       value("LogType"),
+		Operator:      value("Operator"),
+		Message:       value("Message"),
+		OpenVPNConfig: value("OpenVPN_ConfigData_Base64"),
+		Country: Country{
+			Name: value("CountryLong"),
+			Code: strings.ToUpper(value("CountryShort")),
+		},
+	}
+	if err := validateVPNServer(server, vpnGateFields); err != nil {
+		return nil, err
+	}
+
+	score, err := number("Score", 32)
+	if err != nil {
+		return nil, err
+	}
+	ping, err := number("Ping", 32)
+	if err != nil {
+		return nil, err
+	}
+	speed, err := number("Speed", 64)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := number("NumVpnSessions", 32)
+	if err != nil {
+		return nil, err
+	}
+	uptime, err := number("Uptime", 64)
+	if err != nil {
+		return nil, err
+	}
+	users, err := number("TotalUsers", 32)
+	if err != nil {
+		return nil, err
+	}
+	traffic, err := number("TotalTraffic", 64)
+	if err != nil {
+		return nil, err
+	}
+	server.Score = int32(score)
+	server.Ping = int32(ping)
+	server.Speed = speed
+	server.NumVPNSessions = int32(sessions)
+	server.Uptime = uptime
+	server.TotalUsers = int32(users)
+	server.TotalTraffic = traffic
+	return server, nil
+}
+
+// vpnGateFields are the names of the validated fields in the VPNGate CSV header
+var vpnGateFields = serverFieldNames{
+	HostName:      "HostName",
+	IP:            "IP",
+	CountryCode:   "CountryShort",
+	OpenVPNConfig: "OpenVPN_ConfigData_Base64",
+}
+
+// isCountryCode reports whether code is an ISO 3166-1 alpha-2 shaped code
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package vpn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseVPNGateCSV(t *testing.T) {
+	const header = "*vpn_servers\n" +
+		"#HostName,IP,Score,Ping,Speed,CountryLong,CountryShort,NumVpnSessions,Uptime,TotalUsers,TotalTraffic,LogType,Operator,Message,OpenVPN_ConfigData_Base64\n"
+	type args struct {
+		content string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantServers   []*VPNServer
+		wantRows      int
+		wantRejected  []RejectedRow
+		wantTruncated bool
+		wantErr       bool
+	}{
+		{
+			"Feed without header should be error",
+			args{
+				"*vpn_servers\npublic-vpn-1,1.2.3.4,1,2,3,Japan,JP,4,5,6,7,2weeks,op,msg,Y29uZmln\n*\n",
+			},
+			nil,
+			0,
+			nil,
+			false,
+			true,
+		},
+		{
+			"Header without a required column should be error",
+			args{
+				"*vpn_servers\n#HostName,Score\n*\n",
+			},
+			nil,
+			0,
+			nil,
+			false,
+			true,
+		},
+		{
+			"Valid rows and the trailer",
+			args{
+				header +
+					"public-vpn-1,1.2.3.4,100,12,3000,Japan,JP,4,5,6,7,2weeks,op,msg,Y29uZmln\n" +
+					"public-vpn-2,2001:db8::1,1,-,2,Korea Republic of,kr,0,0,0,0,,,,Y29uZmln\n" +
+					"*\n" +
+					"after-trailer,5.6.7.8,1,1,1,Japan,JP,1,1,1,1,,,,Y29uZmln\n",
+			},
+			[]*VPNServer{
+				{
+					HostName: "public-vpn-1", IP: "1.2.3.4", Score: 100, Ping: 12, Speed: 3000,
+					NumVPNSessions: 4, Uptime: 5, TotalUsers: 6, TotalTraffic: 7,
+					LogType: "2weeks", Operator: "op", Message: "msg", OpenVPNConfig: "Y29uZmln",
+					Country: Country{Name: "Japan", Code: "JP"},
+				},
+				{
+					HostName: "public-vpn-2", IP: "2001:db8::1", Score: 1, Speed: 2, OpenVPNConfig: "Y29uZmln",
+					Country: Country{Name: "Korea Republic of", Code: "KR"},
+				},
+			},
+			2,
+			nil,
+			false,
+			false,
+		},
+		{
+			"Columns are mapped by name",
+			args{
+				"#IP,HostName,CountryShort,OpenVPN_ConfigData_Base64\n1.2.3.4,public-vpn-1,JP,Y29uZmln\n*\n",
+			},
+			[]*VPNServer{
+				{HostName: "public-vpn-1", IP: "1.2.3.4", OpenVPNConfig: "Y29uZmln", Country: Country{Code: "JP"}},
+			},
+			1,
+			nil,
+			false,
+			false,
+		},
+		{
+			"Invalid rows are rejected with a reason",
+			args{
+				header +
+					"public-vpn-1,1.2.3.400,1,1,1,Japan,JP,1,1,1,1,,,,Y29uZmln\n" +
+					"public-vpn-2,1.2.3.4,1,1,1,Japan,JPN,1,1,1,1,,,,Y29uZmln\n" +
+					"public-vpn-3,1.2.3.4,1,1,fast,Japan,JP,1,1,1,1,,,,Y29uZmln\n" +
+					"public-vpn-4,1.2.3.4,1,-5,1,Japan,JP,1,1,1,1,,,,Y29uZmln\n" +
+					"public-vpn-5,1.2.3.4,1,1,1,Japan,JP\n" +
+					"public-vpn-6,1.2.3.4,1,1,1,Japan,JP,1,1,1,1,,,,Y29uZmln\n" +
+					"public-vpn-6,1.2.3.4,1,1,1,Japan,JP,1,1,1,1,,,,Y29uZmln\n",
+			},
+			[]*VPNServer{
+				{
+					HostName: "public-vpn-6", IP: "1.2.3.4", Score: 1, Ping: 1, Speed: 1,
+					NumVPNSessions: 1, Uptime: 1, TotalUsers: 1, TotalTraffic: 1, OpenVPNConfig: "Y29uZmln",
+					Country: Country{Name: "Japan", Code: "JP"},
+				},
+			},
+			7,
+			[]RejectedRow{
+				{Line: 3, HostName: "public-vpn-1", IP: "1.2.3.400", Reason: "invalid IP '1.2.3.400'"},
+				{Line: 4, HostName: "public-vpn-2", IP: "1.2.3.4", Reason: "invalid CountryShort 'JPN'"},
+				{Line: 5, HostName: "public-vpn-3", IP: "1.2.3.4", Reason: "invalid Speed 'fast'"},
+				{Line: 6, HostName: "public-vpn-4", IP: "1.2.3.4", Reason: "invalid Ping '-5'"},
+				{Line: 7, HostName: "public-vpn-5", IP: "1.2.3.4", Reason: "expected 15 fields, got 7"},
+				{Line: 9, HostName: "public-vpn-6", IP: "1.2.3.4", Reason: "duplicate of line 8"},
+			},
+			true,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVPNGateCSV([]byte(tt.args.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseVPNGateCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Servers, tt.wantServers) {
+				t.Errorf("parseVPNGateCSV() servers = %+v, want %+v", got.Servers, tt.wantServers)
+			}
+			if got.Rows != tt.wantRows {
+				t.Errorf("parseVPNGateCSV() rows = %v, want %v", got.Rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(got.Rejected, tt.wantRejected) {
+				t.Errorf("parseVPNGateCSV() rejected = %+v, want %+v", got.Rejected, tt.wantRejected)
+			}
+			if got.Truncated != tt.wantTruncated {
+				t.Errorf("parseVPNGateCSV() truncated = %v, want %v", got.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
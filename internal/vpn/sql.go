@@ -0,0 +1,809 @@
+package vpn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// sqlDialect is what the statements shared by the SQL databases need to know of each database
+type sqlDialect struct {
+	// ph is the placeholder format of the database
+	ph sq.PlaceholderFormat
+	// now returns the current time of a statement
+	now func() sq.Sqlizer
+}
+
+// builder returns a statement builder using the placeholders of the database
+func (d sqlDialect) builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(d.ph)
+}
+
+// databaseNow is the clock of databases shared by every replica
+func databaseNow() sq.Sqlizer {
+	return sq.Expr("NOW()")
+}
+
+// applicationNow is the clock of the application in UTC, for databases without a clock shared by the replicas
+func applicationNow() sq.Sqlizer {
+	return sq.Expr("?", time.Now().UTC())
+}
+
+// sqlRepository implements the queries of Repository that every SQL database writes alike. The repository
+// of each database embeds it and adds the statements of its dialect: the upserts, the lease and the way
+// it returns the ids of inserted rows.
+type sqlRepository struct {
+	db *sqlx.DB
+	sqlDialect
+}
+
+// sqlTx is a sqlRepository bound to a transaction
+type sqlTx struct {
+	tx *sqlx.Tx
+	sqlDialect
+}
+
+func (r *sqlRepository) FindCountryByCode(ctx context.Context, code string) (*Country, error) {
+	return findCountryByCode(ctx, r.db, r.ph, code)
+}
+
+func (r *sqlRepository) FindAllCountryHaveVPNServer(ctx context.Context) ([]*Country, error) {
+	query, args, err := r.builder().Select("countries.*").
+		Distinct().
+		From("countries").
+		Join("vpn_servers on vpn_servers.country_id = countries.id").
+		Where(sq.NotEq{"code": ""}).
+		Where(sq.Eq{"vpn_servers.deleted_at": nil}).
+		OrderBy("countries.name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var countries []*Country
+	err = r.db.SelectContext(ctx, &countries, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+func (r *sqlRepository) FindVPNServerByCountryCode(ctx context.Context, code string) ([]*VPNServer, error) {
+	country, err := r.FindCountryByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := selectVPNServers(r.ph).
+		Where(sq.Eq{"country_id": country.ID}).
+		OrderBy("vpn_servers.speed desc").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var vpnServers []*VPNServer
+	err = r.db.SelectContext(ctx, &vpnServers, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return vpnServers, nil
+}
+
+func (r *sqlRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	query, args, err := selectVPNServers(r.ph).
+		OrderBy("vpn_servers.speed desc").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var vpnServers []*VPNServer
+	err = r.db.SelectContext(ctx, &vpnServers, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return vpnServers, nil
+}
+
+func (r *sqlRepository) FindVPNServers(ctx context.Context, query VPNServerQuery) ([]*VPNServer, int64, error) {
+	return findVPNServers(ctx, r.db, r.ph, query)
+}
+
+func (r *sqlRepository) FindVPNServerByID(ctx context.Context, id int32) (*VPNServer, error) {
+	query, args, err := selectVPNServers(r.ph).
+		Where(sq.Eq{"vpn_servers.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	server := VPNServer{}
+	if err := r.db.GetContext(ctx, &server, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVPNServerNotFound
+		}
+		return nil, err
+	}
+	return &server, nil
+}
+
+func (r *sqlRepository) FindProbeTargets(ctx context.Context) ([]*VPNServer, error) {
+	query, args, err := r.builder().Select("id", "ip", "protocol", "port").
+		From("vpn_servers").
+		Where(sq.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var vpnServers []*VPNServer
+	err = r.db.SelectContext(ctx, &vpnServers, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return vpnServers, nil
+}
+
+func (r *sqlRepository) CountVPNServers(ctx context.Context, source string) (int64, error) {
+	query, args, err := r.builder().Select("COUNT(*)").
+		From("vpn_servers").
+		Where(sq.Eq{"source": source, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// reachabilityBatchSize is the number of probe results saved by a single statement
+const reachabilityBatchSize = 500
+
+func (r *sqlRepository) UpdateReachability(ctx context.Context, results []Reachability) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	for len(results) > 0 {
+		n := reachabilityBatchSize
+		if n > len(results) {
+			n = len(results)
+		}
+		update, args, err := r.updateReachability(results[:n], now).ToSql()
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		results = results[n:]
+	}
+	return tx.Commit()
+}
+
+// updateReachability builds the update of the servers of results. The ids and values are written
+// in the statement, they are numbers, so that no database has to guess the type of a CASE placeholder.
+func (r *sqlRepository) updateReachability(results []Reachability, now sq.Sqlizer) sq.UpdateBuilder {
+	var reachable, latency, failures strings.Builder
+	ids := make([]int32, 0, len(results))
+	for _, res := range results {
+		when := " WHEN " + strconv.Itoa(int(res.ServerID)) + " THEN "
+		if res.Reachable {
+			reachable.WriteString(when + "TRUE")
+			failures.WriteString(when + "0")
+		} else {
+			reachable.WriteString(when + "FALSE")
+			failures.WriteString(when + "probe_failures + 1")
+		}
+		latency.WriteString(when + strconv.FormatInt(res.Latency.Milliseconds(), 10))
+		ids = append(ids, res.ServerID)
+	}
+	return r.builder().Update("vpn_servers").
+		Set("reachable", sq.Expr("CASE id"+reachable.String()+" END")).
+		Set("probe_latency_ms", sq.Expr("CASE id"+latency.String()+" END")).
+		Set("probe_failures", sq.Expr("CASE id"+failures.String()+" END")).
+		Set("probed_at", now).
+		Where(sq.Eq{"id": ids})
+}
+
+func (r *sqlRepository) CreateServerRule(ctx context.Context, rule ServerRule) (int64, error) {
+	return lastInsertID(ctx, r.db, r.insertServerRule(rule))
+}
+
+// insertServerRule builds the insert of rule
+func (r *sqlRepository) insertServerRule(rule ServerRule) sq.InsertBuilder {
+	return r.builder().Insert("server_rules").
+		Columns("action",
+			"ip_cidr",
+			"host_name_pattern",
+			"operator_pattern",
+			"country_code",
+			"comment",
+			"expires_at").
+		Values(rule.Action,
+			rule.IPCIDR,
+			rule.HostNamePattern,
+			rule.OperatorPattern,
+			rule.CountryCode,
+			rule.Comment,
+			utcTime(rule.ExpiresAt))
+}
+
+func (r *sqlRepository) FindServerRuleByID(ctx context.Context, id int32) (*ServerRule, error) {
+	query, args, err := r.builder().Select("*").From("server_rules").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rule := ServerRule{}
+	if err := r.db.GetContext(ctx, &rule, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrServerRuleNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *sqlRepository) FindServerRules(ctx context.Context) ([]*ServerRule, error) {
+	query, args, err := r.builder().Select("*").From("server_rules").OrderBy("id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var rules []*ServerRule
+	err = r.db.SelectContext(ctx, &rules, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *sqlRepository) DeleteServerRule(ctx context.Context, id int32) error {
+	query, args, err := r.builder().Delete("server_rules").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrServerRuleNotFound
+	}
+	return nil
+}
+
+func (r *sqlRepository) FindRuleTargets(ctx context.Context) ([]*VPNServer, error) {
+	return findRuleTargets(ctx, r.db, r.ph)
+}
+
+func (r *sqlRepository) FindServerRulesVersion(ctx context.Context) (ServerRulesVersion, error) {
+	return findServerRulesVersion(ctx, r.db, r.ph)
+}
+
+// transaction runs fn in a transaction which is committed when fn succeeds and rolled back otherwise
+func (r *sqlRepository) transaction(ctx context.Context, fn func(sqlTx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(sqlTx{tx, r.sqlDialect}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlRepository) PurgeSnapshots(ctx context.Context, source string) error {
+	smt, args, err := r.builder().Delete("snapshots").
+		Where(sq.Eq{"source": source, "active": false}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, smt, args...)
+	return err
+}
+
+func (r *sqlRepository) CreateCrawlRun(ctx context.Context, run CrawlRun) (int64, error) {
+	return lastInsertID(ctx, r.db, r.insertCrawlRun(run))
+}
+
+// insertCrawlRun builds the insert of run
+func (r *sqlRepository) insertCrawlRun(run CrawlRun) sq.InsertBuilder {
+	return r.builder().Insert("crawl_runs").
+		Columns("source", "status", "started_at", "error").
+		Values(run.Source, run.Status, run.StartedAt.UTC(), run.Error)
+}
+
+func (r *sqlRepository) UpdateCrawlRun(ctx context.Context, run CrawlRun) error {
+	update, args, err := r.builder().Update("crawl_runs").
+		Set("status", run.Status).
+		Set("finished_at", utcTime(run.FinishedAt)).
+		Set("rows_fetched", run.RowsFetched).
+		Set("rows_parsed", run.RowsParsed).
+		Set("rows_rejected", run.RowsRejected).
+		Set("inserted", run.Inserted).
+		Set("updated", run.Updated).
+		Set("removed", run.Removed).
+		Set("db_time_ms", run.DBTimeMs).
+		Set("error", run.Error).
+		Where(sq.Eq{"id": run.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, update, args...)
+	return err
+}
+
+func (r *sqlRepository) FindCrawlRunByID(ctx context.Context, id int32) (*CrawlRun, error) {
+	query, args, err := r.builder().Select("*").From("crawl_runs").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	run := CrawlRun{}
+	if err := r.db.GetContext(ctx, &run, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCrawlRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *sqlRepository) FindCrawlRuns(ctx context.Context, source string, limit uint64) ([]*CrawlRun, error) {
+	builder := r.builder().Select("*").
+		From("crawl_runs").
+		OrderBy("started_at desc", "id desc").
+		Limit(limit)
+	if len(source) > 0 {
+		builder = builder.Where(sq.Eq{"source": source})
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var runs []*CrawlRun
+	err = r.db.SelectContext(ctx, &runs, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (r *sqlRepository) FindServerMetrics(ctx context.Context, serverID int32, from, to time.Time) ([]*ServerMetric, error) {
+	query, args, err := r.builder().Select("*").
+		From("server_metrics").
+		Where(sq.Eq{"server_id": serverID}).
+		Where(sq.GtOrEq{"crawled_at": from.UTC()}).
+		Where(sq.Lt{"crawled_at": to.UTC()}).
+		OrderBy("crawled_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var metrics []*ServerMetric
+	err = r.db.SelectContext(ctx, &metrics, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (r *sqlRepository) PruneServerMetrics(ctx context.Context, before time.Time) (int64, error) {
+	smt, args, err := r.builder().Delete("server_metrics").
+		Where(sq.Lt{"crawled_at": before.UTC()}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	result, err := r.db.ExecContext(ctx, smt, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// acquireLease runs upsert, the lease statement of the database, and reports whether holder holds the lease name
+func (r *sqlRepository) acquireLease(ctx context.Context, name, holder string, upsert sq.InsertBuilder) (bool, error) {
+	insert, args, err := upsert.ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.db.ExecContext(ctx, insert, args...); err != nil {
+		return false, err
+	}
+	query, args, err := r.builder().Select("holder").From("leader_leases").Where(sq.Eq{"name": name}).ToSql()
+	if err != nil {
+		return false, err
+	}
+	var current string
+	if err := r.db.GetContext(ctx, &current, query, args...); err != nil {
+		return false, err
+	}
+	return current == holder, nil
+}
+
+func (r *sqlRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	smt, args, err := r.builder().Delete("leader_leases").
+		Where(sq.Eq{"name": name, "holder": holder}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, smt, args...)
+	return err
+}
+
+func (t *sqlTx) ResolveCountries(ctx context.Context, countries []Country) (map[string]int32, error) {
+	ids, insert, missing, err := t.missingCountries(ctx, countries)
+	if err != nil || len(missing) == 0 {
+		return ids, err
+	}
+	smt, args, err := insert.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.tx.ExecContext(ctx, smt, args...); err != nil {
+		return nil, err
+	}
+	// the ids of a multi-row insert are not guaranteed to be consecutive so they are selected back
+	inserted, err := findCountryIDs(ctx, t.tx, t.ph, missing)
+	if err != nil {
+		return nil, err
+	}
+	for code, id := range inserted {
+		ids[code] = id
+	}
+	return ids, nil
+}
+
+// missingCountries maps the codes of the existing countries to their ids and builds the insert
+// of the missing ones
+func (t *sqlTx) missingCountries(ctx context.Context, countries []Country) (map[string]int32, sq.InsertBuilder, []string, error) {
+	names := make(map[string]string)
+	var codes []string
+	for _, c := range countries {
+		if _, ok := names[c.Code]; !ok {
+			names[c.Code] = c.Name
+			codes = append(codes, c.Code)
+		}
+	}
+	insert := t.builder().Insert("countries").Columns("name", "code")
+	ids, err := findCountryIDs(ctx, t.tx, t.ph, codes)
+	if err != nil {
+		return nil, insert, nil, err
+	}
+	var missing []string
+	for _, code := range codes {
+		if _, ok := ids[code]; !ok {
+			insert = insert.Values(names[code], code)
+			missing = append(missing, code)
+		}
+	}
+	return ids, insert, missing, nil
+}
+
+func (t *sqlTx) CreateSnapshot(ctx context.Context, source string) (int64, error) {
+	return lastInsertID(ctx, t.tx, t.insertSnapshot(source))
+}
+
+// insertSnapshot builds the insert of an inactive snapshot of source
+func (t *sqlTx) insertSnapshot(source string) sq.InsertBuilder {
+	return t.builder().Insert("snapshots").
+		Columns("source", "active").
+		Values(source, false)
+}
+
+// insertServers builds the insert of servers, the upsert of each database adds the update of the existing rows
+func (t *sqlTx) insertServers(servers []*VPNServer) sq.InsertBuilder {
+	insert := t.builder().Insert("vpn_servers").
+		Columns("host_name",
+			"ip",
+			"score",
+			"ping",
+			"speed",
+			"country_id",
+			"num_vpn_sessions",
+			"uptime",
+			"total_users",
+			"total_traffic",
+			"log_type",
+			"operator",
+			"message",
+			"open_vpn_config",
+			"protocol",
+			"port",
+			"remote_hosts",
+			"cipher",
+			"auth_digest",
+			"compression",
+			"embeds_ca",
+			"embeds_cert",
+			"embeds_key",
+			"latitude",
+			"longitude",
+			"source",
+			"snapshot_id",
+			"last_seen_at")
+	now := t.now()
+	for _, server := range servers {
+		insert = insert.Values(server.HostName,
+			server.IP,
+			server.Score,
+			server.Ping,
+			server.Speed,
+			server.CountryID,
+			server.NumVPNSessions,
+			server.Uptime,
+			server.TotalUsers,
+			server.TotalTraffic,
+			server.LogType,
+			server.Operator,
+			server.Message,
+			server.OpenVPNConfig,
+			server.Protocol,
+			server.Port,
+			server.RemoteHosts,
+			server.Cipher,
+			server.AuthDigest,
+			server.Compression,
+			server.EmbedsCA,
+			server.EmbedsCert,
+			server.EmbedsKey,
+			server.Latitude,
+			server.Longitude,
+			server.Source,
+			server.SnapshotID,
+			now)
+	}
+	return insert
+}
+
+// upsertedServerColumns are the columns of vpn_servers an upsert updates, every column but the key
+var upsertedServerColumns = []string{
+	"score",
+	"ping",
+	"speed",
+	"country_id",
+	"num_vpn_sessions",
+	"uptime",
+	"total_users",
+	"total_traffic",
+	"log_type",
+	"operator",
+	"message",
+	"open_vpn_config",
+	"protocol",
+	"port",
+	"remote_hosts",
+	"cipher",
+	"auth_digest",
+	"compression",
+	"embeds_ca",
+	"embeds_cert",
+	"embeds_key",
+	"latitude",
+	"longitude",
+	"snapshot_id",
+	"last_seen_at",
+}
+
+// upsertServerAssignments returns the assignments of an upsert of vpn_servers which restore a soft
+// deleted server, value is the format of the inserted value of a column e.g. excluded.%s
+func upsertServerAssignments(value string) string {
+	assignments := make([]string, 0, len(upsertedServerColumns)+1)
+	for _, column := range upsertedServerColumns {
+		assignments = append(assignments, column+" = "+fmt.Sprintf(value, column))
+	}
+	assignments = append(assignments, "deleted_at = NULL")
+	return strings.Join(assignments, ",\n\t\t\t")
+}
+
+func (t *sqlTx) DeleteMissing(ctx context.Context, source string, snapshotID int64) (int64, error) {
+	update, args, err := t.builder().Update("vpn_servers").
+		Set("deleted_at", t.now()).
+		Where(sq.Eq{"source": source, "deleted_at": nil}).
+		Where(sq.NotEq{"snapshot_id": snapshotID}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	result, err := t.tx.ExecContext(ctx, update, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (t *sqlTx) RecordMetrics(ctx context.Context, snapshotID int64) (int64, error) {
+	insert, args, err := t.builder().Insert("server_metrics").
+		Columns("server_id",
+			"crawled_at",
+			"score",
+			"ping",
+			"speed",
+			"num_vpn_sessions",
+			"total_users").
+		Select(sq.Select("id").
+			Column(t.now()).
+			Columns("score",
+				"ping",
+				"speed",
+				"num_vpn_sessions",
+				"total_users").
+			From("vpn_servers").
+			Where(sq.Eq{"snapshot_id": snapshotID, "deleted_at": nil})).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	result, err := t.tx.ExecContext(ctx, insert, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (t *sqlTx) ActivateSnapshot(ctx context.Context, source string, id int64) error {
+	update, args, err := t.builder().Update("snapshots").
+		Set("active", sq.Expr("id = ?", id)).
+		Where(sq.Eq{"source": source}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = t.tx.ExecContext(ctx, update, args...)
+	return err
+}
+
+// lastInsertID runs insert with e and returns the id of the inserted row, for the databases reporting it
+func lastInsertID(ctx context.Context, e sqlx.ExecerContext, insert sq.InsertBuilder) (int64, error) {
+	smt, args, err := insert.ToSql()
+	if err != nil {
+		return 0, err
+	}
+	result, err := e.ExecContext(ctx, smt, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// utcTime returns t in UTC, nil when t is nil
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// findCountryByCode finds a country by code with q, which is either the database or a transaction.
+// ph is the placeholder format of the database.
+func findCountryByCode(ctx context.Context, q sqlx.QueryerContext, ph sq.PlaceholderFormat, code string) (*Country, error) {
+	query, args, err := sq.Select("*").From("countries").Where(sq.Eq{"code": code}).PlaceholderFormat(ph).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	c := Country{}
+	if err := sqlx.GetContext(ctx, q, &c, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCountryNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// findCountryIDs maps the codes of the existing countries to their ids with q
+func findCountryIDs(ctx context.Context, q sqlx.QueryerContext, ph sq.PlaceholderFormat, codes []string) (map[string]int32, error) {
+	ids := make(map[string]int32)
+	if len(codes) == 0 {
+		return ids, nil
+	}
+	query, args, err := sq.Select("id", "code").From("countries").Where(sq.Eq{"code": codes}).PlaceholderFormat(ph).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var countries []*Country
+	if err := sqlx.SelectContext(ctx, q, &countries, query, args...); err != nil {
+		return nil, err
+	}
+	for _, c := range countries {
+		ids[c.Code] = c.ID
+	}
+	return ids, nil
+}
+
+// findVPNServers finds a page of the VPN servers matching query and counts every match with q
+func findVPNServers(ctx context.Context, q sqlx.QueryerContext, ph sq.PlaceholderFormat, query VPNServerQuery) ([]*VPNServer, int64, error) {
+	var countryID int32
+	if len(query.CountryCode) > 0 {
+		country, err := findCountryByCode(ctx, q, ph, query.CountryCode)
+		if err != nil {
+			return nil, 0, err
+		}
+		countryID = country.ID
+	}
+	where := query.filters(countryID)
+	count, args, err := sq.Select("COUNT(*)").From("vpn_servers").Where(where).PlaceholderFormat(ph).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	if err := sqlx.GetContext(ctx, q, &total, count, args...); err != nil {
+		return nil, 0, err
+	}
+	page, args, err := query.page(selectVPNServers(ph).Where(where)).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+	var servers []*VPNServer
+	if err := sqlx.SelectContext(ctx, q, &servers, page, args...); err != nil {
+		return nil, 0, err
+	}
+	return servers, total, nil
+}
+
+// findRuleTargets finds the VPN servers with the columns server rules match on with q
+func findRuleTargets(ctx context.Context, q sqlx.QueryerContext, ph sq.PlaceholderFormat) ([]*VPNServer, error) {
+	query, args, err := sq.Select("vpn_servers.id",
+		"vpn_servers.ip",
+		"vpn_servers.host_name",
+		"vpn_servers.operator",
+		`COALESCE(countries.code, '') "country.code"`).
+		From("vpn_servers").
+		LeftJoin("countries on countries.id = vpn_servers.country_id").
+		Where(sq.Eq{"vpn_servers.deleted_at": nil}).
+		PlaceholderFormat(ph).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var targets []*VPNServer
+	if err := sqlx.SelectContext(ctx, q, &targets, query, args...); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// findServerRulesVersion returns the version of the server rules with q
+func findServerRulesVersion(ctx context.Context, q sqlx.QueryerContext, ph sq.PlaceholderFormat) (ServerRulesVersion, error) {
+	query, args, err := sq.Select("COUNT(*) AS count", "COALESCE(MAX(id), 0) AS max_id", "MAX(updated_at) AS updated_at").
+		From("server_rules").
+		PlaceholderFormat(ph).
+		ToSql()
+	if err != nil {
+		return ServerRulesVersion{}, err
+	}
+	version := ServerRulesVersion{}
+	if err := sqlx.GetContext(ctx, q, &version, query, args...); err != nil {
+		return ServerRulesVersion{}, err
+	}
+	return version, nil
+}
+
+// selectVPNServers selects VPN servers that are not soft deleted joined with their country
+func selectVPNServers(ph sq.PlaceholderFormat) sq.SelectBuilder {
+	return sq.Select(`vpn_servers.*,
+		countries.name "country.name",
+		countries.code "country.code",
+		countries.id "country.id"`).
+		Distinct().
+		From("vpn_servers").
+		LeftJoin("countries on countries.id = vpn_servers.country_id").
+		Where(sq.Eq{"vpn_servers.deleted_at": nil}).
+		PlaceholderFormat(ph)
+}
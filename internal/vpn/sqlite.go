@@ -0,0 +1,73 @@
+package vpn
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName is name of the SQLite database driver
+const sqliteDriverName = "sqlite3"
+
+// sqliteRepository is the SQLite implementation of Repository. SQLite has no server clock shared
+// between replicas so the timestamps it compares are taken from the application clock in UTC.
+type sqliteRepository struct {
+	sqlRepository
+}
+
+// sqliteTx is a sqliteRepository bound to a transaction
+type sqliteTx struct {
+	sqlTx
+}
+
+func (s *sqliteRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	return s.transaction(ctx, func(tx sqlTx) error {
+		return fn(&sqliteTx{tx})
+	})
+}
+
+func (s *sqliteRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	// SQLite evaluates every assignment against the old row, unlike MySQL,
+	// so both assignments repeat the ownership condition
+	now := time.Now().UTC()
+	upsert := s.builder().Insert("leader_leases").
+		Columns("name", "holder", "expires_at").
+		Values(name, holder, now.Add(ttl)).
+		Suffix(`ON CONFLICT (name) DO UPDATE SET
+			holder = CASE WHEN holder = excluded.holder OR expires_at < ? THEN excluded.holder ELSE holder END,
+			expires_at = CASE WHEN holder = excluded.holder OR expires_at < ? THEN excluded.expires_at ELSE expires_at END`,
+			now, now)
+	return s.acquireLease(ctx, name, holder, upsert)
+}
+
+func (t *sqliteTx) UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error) {
+	if len(servers) == 0 {
+		return 0, 0, nil
+	}
+	// SQLite reports 1 affected row for both inserts and updates so the existing servers are counted first
+	existing := sq.Or{}
+	for _, server := range servers {
+		existing = append(existing, sq.Eq{"source": server.Source, "ip": server.IP, "host_name": server.HostName})
+	}
+	query, args, err := t.builder().Select("COUNT(*)").From("vpn_servers").Where(existing).ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	var updated int64
+	if err := t.tx.GetContext(ctx, &updated, query, args...); err != nil {
+		return 0, 0, err
+	}
+
+	smt, args, err := t.insertServers(servers).
+		Suffix("ON CONFLICT (source, ip, host_name) DO UPDATE SET\n\t\t\t" + upsertServerAssignments("excluded.%s")).
+		ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := t.tx.ExecContext(ctx, smt, args...); err != nil {
+		return 0, 0, err
+	}
+	return int64(len(servers)) - updated, updated, nil
+}
@@ -0,0 +1,47 @@
+package vpn
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// newTestSQLiteRepository creates a repository backed by a migrated in-memory SQLite database
+func newTestSQLiteRepository(t *testing.T) Repository {
+	db, err := sqlx.Connect(sqliteDriverName, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to :memory: is a distinct database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := newMigrate(db.DB, sqliteDriverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	return NewRepository(db)
+}
+
+func Test_sqliteRepository_crawl(t *testing.T) {
+	testRepositoryCrawl(t, newTestSQLiteRepository(t))
+}
+
+func Test_sqliteRepository_FindVPNServers(t *testing.T) {
+	testRepositoryFindVPNServers(t, newTestSQLiteRepository(t))
+}
+
+func Test_sqliteRepository_AcquireLease(t *testing.T) {
+	testRepositoryAcquireLease(t, newTestSQLiteRepository(t))
+}
+
+func Test_sqliteRepository_FindServerRulesVersion(t *testing.T) {
+	testRepositoryFindServerRulesVersion(t, newTestSQLiteRepository(t))
+}
+
+func Test_sqliteRepository_UpdateReachability(t *testing.T) {
+	testRepositoryUpdateReachability(t, newTestSQLiteRepository(t))
+}
@@ -0,0 +1,234 @@
+package vpn
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// timeoutRepository bounds every call of a Repository with a default timeout so a hung database fails
+// the call instead of piling up blocked goroutines. A deadline of the caller that comes first is kept.
+type timeoutRepository struct {
+	repo Repository
+	// queryTimeout bounds a single query, txTimeout a whole transaction, zero disables the bound
+	queryTimeout time.Duration
+	txTimeout    time.Duration
+}
+
+// timeoutTx bounds every statement of a transaction with the query timeout
+type timeoutTx struct {
+	tx           Tx
+	queryTimeout time.Duration
+}
+
+// withTimeout derives a context from ctx that is done after d, or only when ctx is when d is not positive
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+func (r *timeoutRepository) FindCountryByCode(ctx context.Context, code string) (*Country, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindCountryByCode(ctx, code)
+}
+
+func (r *timeoutRepository) FindAllCountryHaveVPNServer(ctx context.Context) ([]*Country, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindAllCountryHaveVPNServer(ctx)
+}
+
+func (r *timeoutRepository) FindVPNServerByCountryCode(ctx context.Context, code string) ([]*VPNServer, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindVPNServerByCountryCode(ctx, code)
+}
+
+func (r *timeoutRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindAllVPNServer(ctx)
+}
+
+func (r *timeoutRepository) FindVPNServers(ctx context.Context, query VPNServerQuery) ([]*VPNServer, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindVPNServers(ctx, query)
+}
+
+func (r *timeoutRepository) FindVPNServerByID(ctx context.Context, id int32) (*VPNServer, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindVPNServerByID(ctx, id)
+}
+
+func (r *timeoutRepository) FindProbeTargets(ctx context.Context) ([]*VPNServer, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindProbeTargets(ctx)
+}
+
+func (r *timeoutRepository) CountVPNServers(ctx context.Context, source string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.CountVPNServers(ctx, source)
+}
+
+func (r *timeoutRepository) UpdateReachability(ctx context.Context, results []Reachability) error {
+	// every server is updated by its own statement of a single transaction
+	ctx, cancel := withTimeout(ctx, r.txTimeout)
+	defer cancel()
+	return r.repo.UpdateReachability(ctx, results)
+}
+
+func (r *timeoutRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	ctx, cancel := withTimeout(ctx, r.txTimeout)
+	defer cancel()
+	err := r.repo.Transaction(ctx, func(tx Tx) error {
+		return fn(&timeoutTx{tx: tx, queryTimeout: r.queryTimeout})
+	})
+	// the statements of a transaction that timed out fail because it was rolled back, not because of ctx
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+	}
+	return err
+}
+
+func (r *timeoutRepository) PurgeSnapshots(ctx context.Context, source string) error {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.PurgeSnapshots(ctx, source)
+}
+
+func (r *timeoutRepository) CreateCrawlRun(ctx context.Context, run CrawlRun) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.CreateCrawlRun(ctx, run)
+}
+
+func (r *timeoutRepository) UpdateCrawlRun(ctx context.Context, run CrawlRun) error {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.UpdateCrawlRun(ctx, run)
+}
+
+func (r *timeoutRepository) FindCrawlRunByID(ctx context.Context, id int32) (*CrawlRun, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindCrawlRunByID(ctx, id)
+}
+
+func (r *timeoutRepository) FindCrawlRuns(ctx context.Context, source string, limit uint64) ([]*CrawlRun, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindCrawlRuns(ctx, source, limit)
+}
+
+func (r *timeoutRepository) FindServerMetrics(ctx context.Context, serverID int32, from, to time.Time) ([]*ServerMetric, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindServerMetrics(ctx, serverID, from, to)
+}
+
+func (r *timeoutRepository) PruneServerMetrics(ctx context.Context, before time.Time) (int64, error) {
+	// pruning deletes up to a day of metrics of every server at once
+	ctx, cancel := withTimeout(ctx, r.txTimeout)
+	defer cancel()
+	return r.repo.PruneServerMetrics(ctx, before)
+}
+
+func (r *timeoutRepository) CreateServerRule(ctx context.Context, rule ServerRule) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.CreateServerRule(ctx, rule)
+}
+
+func (r *timeoutRepository) FindServerRuleByID(ctx context.Context, id int32) (*ServerRule, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindServerRuleByID(ctx, id)
+}
+
+func (r *timeoutRepository) FindServerRules(ctx context.Context) ([]*ServerRule, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindServerRules(ctx)
+}
+
+func (r *timeoutRepository) DeleteServerRule(ctx context.Context, id int32) error {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.DeleteServerRule(ctx, id)
+}
+
+func (r *timeoutRepository) FindRuleTargets(ctx context.Context) ([]*VPNServer, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindRuleTargets(ctx)
+}
+
+func (r *timeoutRepository) FindServerRulesVersion(ctx context.Context) (ServerRulesVersion, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.FindServerRulesVersion(ctx)
+}
+
+func (r *timeoutRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.AcquireLease(ctx, name, holder, ttl)
+}
+
+func (r *timeoutRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	ctx, cancel := withTimeout(ctx, r.queryTimeout)
+	defer cancel()
+	return r.repo.ReleaseLease(ctx, name, holder)
+}
+
+func (t *timeoutTx) ResolveCountries(ctx context.Context, countries []Country) (map[string]int32, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.ResolveCountries(ctx, countries)
+}
+
+func (t *timeoutTx) CreateSnapshot(ctx context.Context, source string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.CreateSnapshot(ctx, source)
+}
+
+func (t *timeoutTx) UpsertBatch(ctx context.Context, servers []*VPNServer) (int64, int64, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.UpsertBatch(ctx, servers)
+}
+
+func (t *timeoutTx) DeleteMissing(ctx context.Context, source string, snapshotID int64) (int64, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.DeleteMissing(ctx, source, snapshotID)
+}
+
+func (t *timeoutTx) RecordMetrics(ctx context.Context, snapshotID int64) (int64, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.RecordMetrics(ctx, snapshotID)
+}
+
+func (t *timeoutTx) ActivateSnapshot(ctx context.Context, source string, id int64) error {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.ActivateSnapshot(ctx, source, id)
+}
+
+// NewTimeoutRepository bounds the queries of repo with queryTimeout and its transactions with txTimeout
+func NewTimeoutRepository(repo Repository, queryTimeout, txTimeout time.Duration) Repository {
+	return &timeoutRepository{
+		repo:         repo,
+		queryTimeout: queryTimeout,
+		txTimeout:    txTimeout,
+	}
+}
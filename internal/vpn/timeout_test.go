@@ -0,0 +1,59 @@
+package vpn
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hungRepository is a repository whose queries never finish before ctx is done
+type hungRepository struct {
+	Repository
+}
+
+func (h *hungRepository) FindAllVPNServer(ctx context.Context) ([]*VPNServer, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (h *hungRepository) Transaction(ctx context.Context, fn func(Tx) error) error {
+	<-ctx.Done()
+	// a rolled back transaction does not report why it was rolled back
+	return errTxDone
+}
+
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+func Test_timeoutRepository(t *testing.T) {
+	repo := NewTimeoutRepository(&hungRepository{}, 10*time.Millisecond, 20*time.Millisecond)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "query",
+			call: func() error {
+				_, err := repo.FindAllVPNServer(context.Background())
+				return err
+			},
+		},
+		{
+			name: "transaction",
+			call: func() error {
+				return repo.Transaction(context.Background(), func(Tx) error { return nil })
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := status.Code(repositoryError(err)); got != codes.DeadlineExceeded {
+				t.Errorf("code = %v, want %v (%v)", got, codes.DeadlineExceeded, err)
+			}
+		})
+	}
+}
@@ -14,7 +14,15 @@ func main() {
 		version.Commit, version.BuildTime, version.Release,
 	)
 
-	if err := vpn.RunServer(); err != nil {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		err = vpn.RunReplay(os.Args[2:])
+	} else if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = vpn.RunMigrate(os.Args[2:])
+	} else {
+		err = vpn.RunServer(os.Args[1:])
+	}
+	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,9 @@
+// Package migrations embeds the migration sets of the database drivers, migrations of driver d are in d/
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed mysql/*.sql sqlite/*.sql postgres/*.sql
+var FS embed.FS
@@ -0,0 +1,3 @@
+ALTER TABLE vpn_servers
+  DROP COLUMN longitude,
+  DROP COLUMN latitude;
@@ -0,0 +1,3 @@
+ALTER TABLE vpn_servers
+  ADD COLUMN latitude  DOUBLE DEFAULT NULL AFTER embeds_key,
+  ADD COLUMN longitude DOUBLE DEFAULT NULL AFTER latitude;
@@ -0,0 +1 @@
+DROP TABLE server_rules;
@@ -0,0 +1,13 @@
+CREATE TABLE server_rules
+(
+  id                INT(11)      NOT NULL PRIMARY KEY AUTO_INCREMENT,
+  created_at        DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at        DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  action            VARCHAR(8)   NOT NULL,
+  ip_cidr           VARCHAR(64)  NOT NULL DEFAULT '',
+  host_name_pattern VARCHAR(255) NOT NULL DEFAULT '',
+  operator_pattern  VARCHAR(255) NOT NULL DEFAULT '',
+  country_code      VARCHAR(2)   NOT NULL DEFAULT '',
+  comment           VARCHAR(255) NOT NULL DEFAULT '',
+  expires_at        DATETIME              DEFAULT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE crawl_runs
+  ADD COLUMN db_time_ms INT(11) NOT NULL DEFAULT 0 AFTER removed;
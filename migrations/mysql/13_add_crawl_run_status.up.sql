@@ -0,0 +1,6 @@
+ALTER TABLE crawl_runs
+  ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'running' AFTER source;
+
+UPDATE crawl_runs
+SET status = IF(error = '', 'succeeded', 'failed')
+WHERE finished_at IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE vpn_servers
+  DROP KEY idx_speed_id,
+  DROP KEY idx_ping_id,
+  DROP KEY idx_score_id,
+  DROP KEY idx_num_vpn_sessions_id;
@@ -0,0 +1,5 @@
+ALTER TABLE vpn_servers
+  ADD KEY idx_speed_id (speed, id),
+  ADD KEY idx_ping_id (ping, id),
+  ADD KEY idx_score_id (score, id),
+  ADD KEY idx_num_vpn_sessions_id (num_vpn_sessions, id);
@@ -0,0 +1,3 @@
+ALTER TABLE vpn_servers
+  DROP KEY idx_source,
+  DROP COLUMN source;
@@ -0,0 +1,3 @@
+ALTER TABLE vpn_servers
+  ADD COLUMN source VARCHAR(64) NOT NULL DEFAULT 'vpngate' AFTER deleted_at,
+  ADD KEY idx_source (source);
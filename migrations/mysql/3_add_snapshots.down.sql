@@ -0,0 +1,5 @@
+ALTER TABLE vpn_servers
+  DROP KEY idx_snapshot_id,
+  DROP COLUMN snapshot_id;
+
+DROP TABLE snapshots;
@@ -0,0 +1,21 @@
+CREATE TABLE snapshots
+(
+  id         INT(11)     NOT NULL PRIMARY KEY AUTO_INCREMENT,
+  created_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  source     VARCHAR(64) NOT NULL,
+  active     TINYINT(1)  NOT NULL DEFAULT 0,
+  KEY idx_source_active (source, active)
+);
+
+ALTER TABLE vpn_servers
+  ADD COLUMN snapshot_id INT(11) AFTER source,
+  ADD KEY idx_snapshot_id (snapshot_id);
+
+INSERT INTO snapshots (source, active)
+SELECT DISTINCT source, 1
+FROM vpn_servers;
+
+UPDATE vpn_servers
+  INNER JOIN snapshots ON snapshots.source = vpn_servers.source
+SET vpn_servers.snapshot_id = snapshots.id;
@@ -0,0 +1,6 @@
+ALTER TABLE vpn_servers
+  DROP KEY idx_deleted_at,
+  DROP KEY uid_source_ip_host_name,
+  DROP COLUMN last_seen_at,
+  MODIFY ip VARCHAR(255),
+  MODIFY host_name VARCHAR(255);
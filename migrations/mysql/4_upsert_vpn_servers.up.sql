@@ -0,0 +1,22 @@
+DELETE vpn_servers
+FROM vpn_servers
+  LEFT JOIN snapshots ON snapshots.id = vpn_servers.snapshot_id
+WHERE snapshots.active IS NULL OR snapshots.active = 0;
+
+DELETE older
+FROM vpn_servers older
+  INNER JOIN vpn_servers newer ON newer.source = older.source
+    AND newer.ip = older.ip
+    AND newer.host_name = older.host_name
+    AND newer.id > older.id;
+
+UPDATE vpn_servers
+SET host_name = COALESCE(host_name, ''),
+    ip        = COALESCE(ip, '');
+
+ALTER TABLE vpn_servers
+  MODIFY host_name VARCHAR(255) NOT NULL DEFAULT '',
+  MODIFY ip VARCHAR(45) NOT NULL DEFAULT '',
+  ADD COLUMN last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER deleted_at,
+  ADD UNIQUE KEY uid_source_ip_host_name (source, ip, host_name),
+  ADD KEY idx_deleted_at (deleted_at);
@@ -0,0 +1,17 @@
+CREATE TABLE crawl_runs
+(
+  id            INT(11)     NOT NULL PRIMARY KEY AUTO_INCREMENT,
+  created_at    DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at    DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  source        VARCHAR(64) NOT NULL,
+  started_at    DATETIME    NOT NULL,
+  finished_at   DATETIME             DEFAULT NULL,
+  rows_fetched  INT(11)     NOT NULL DEFAULT 0,
+  rows_parsed   INT(11)     NOT NULL DEFAULT 0,
+  rows_rejected INT(11)     NOT NULL DEFAULT 0,
+  inserted      INT(11)     NOT NULL DEFAULT 0,
+  updated       INT(11)     NOT NULL DEFAULT 0,
+  removed       INT(11)     NOT NULL DEFAULT 0,
+  error         TEXT        NOT NULL,
+  KEY idx_source_started_at (source, started_at)
+);
@@ -0,0 +1 @@
+DROP TABLE leader_leases;
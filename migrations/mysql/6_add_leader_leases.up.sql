@@ -0,0 +1,8 @@
+CREATE TABLE leader_leases
+(
+  name       VARCHAR(64)  NOT NULL PRIMARY KEY,
+  created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  holder     VARCHAR(255) NOT NULL,
+  expires_at DATETIME(3)  NOT NULL
+);
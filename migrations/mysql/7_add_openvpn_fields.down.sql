@@ -0,0 +1,11 @@
+ALTER TABLE vpn_servers
+  DROP KEY idx_protocol_port,
+  DROP COLUMN embeds_key,
+  DROP COLUMN embeds_cert,
+  DROP COLUMN embeds_ca,
+  DROP COLUMN compression,
+  DROP COLUMN auth_digest,
+  DROP COLUMN cipher,
+  DROP COLUMN remote_hosts,
+  DROP COLUMN port,
+  DROP COLUMN protocol;
@@ -0,0 +1,11 @@
+ALTER TABLE vpn_servers
+  ADD COLUMN protocol     VARCHAR(8)    NOT NULL DEFAULT '' AFTER open_vpn_config,
+  ADD COLUMN port         INT(11)       NOT NULL DEFAULT 0 AFTER protocol,
+  ADD COLUMN remote_hosts VARCHAR(1024) NOT NULL DEFAULT '' AFTER port,
+  ADD COLUMN cipher       VARCHAR(64)   NOT NULL DEFAULT '' AFTER remote_hosts,
+  ADD COLUMN auth_digest  VARCHAR(64)   NOT NULL DEFAULT '' AFTER cipher,
+  ADD COLUMN compression  VARCHAR(32)   NOT NULL DEFAULT '' AFTER auth_digest,
+  ADD COLUMN embeds_ca    TINYINT(1)    NOT NULL DEFAULT 0 AFTER compression,
+  ADD COLUMN embeds_cert  TINYINT(1)    NOT NULL DEFAULT 0 AFTER embeds_ca,
+  ADD COLUMN embeds_key   TINYINT(1)    NOT NULL DEFAULT 0 AFTER embeds_cert,
+  ADD KEY idx_protocol_port (protocol, port);
@@ -0,0 +1,13 @@
+CREATE TABLE server_metrics
+(
+  id               BIGINT   NOT NULL PRIMARY KEY AUTO_INCREMENT,
+  server_id        INT(11)  NOT NULL,
+  crawled_at       DATETIME NOT NULL,
+  score            BIGINT   NOT NULL DEFAULT 0,
+  ping             INT(11)  NOT NULL DEFAULT 0,
+  speed            BIGINT   NOT NULL DEFAULT 0,
+  num_vpn_sessions BIGINT   NOT NULL DEFAULT 0,
+  total_users      BIGINT   NOT NULL DEFAULT 0,
+  KEY idx_server_id_crawled_at (server_id, crawled_at),
+  KEY idx_crawled_at (crawled_at)
+);
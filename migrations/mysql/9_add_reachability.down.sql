@@ -0,0 +1,5 @@
+ALTER TABLE vpn_servers
+  DROP COLUMN probed_at,
+  DROP COLUMN probe_failures,
+  DROP COLUMN probe_latency_ms,
+  DROP COLUMN reachable;
@@ -0,0 +1,5 @@
+ALTER TABLE vpn_servers
+  ADD COLUMN reachable        TINYINT(1) NOT NULL DEFAULT 0 AFTER embeds_key,
+  ADD COLUMN probe_latency_ms INT(11)    NOT NULL DEFAULT 0 AFTER reachable,
+  ADD COLUMN probe_failures   INT(11)    NOT NULL DEFAULT 0 AFTER probe_latency_ms,
+  ADD COLUMN probed_at        DATETIME            DEFAULT NULL AFTER probe_failures;
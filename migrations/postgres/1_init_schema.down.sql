@@ -0,0 +1,8 @@
+DROP TABLE server_rules;
+DROP TABLE server_metrics;
+DROP TABLE leader_leases;
+DROP TABLE crawl_runs;
+DROP TABLE snapshots;
+DROP TABLE vpn_servers;
+DROP TABLE countries;
+DROP FUNCTION set_updated_at();
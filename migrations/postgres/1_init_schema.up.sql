@@ -0,0 +1,138 @@
+CREATE TABLE countries
+(
+  id         SERIAL       NOT NULL PRIMARY KEY,
+  created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMPTZ           DEFAULT NULL,
+  name       VARCHAR(255) NOT NULL,
+  code       VARCHAR(255) NOT NULL
+);
+CREATE UNIQUE INDEX uid_countries_code ON countries (code);
+CREATE INDEX idx_countries_name ON countries (name);
+
+CREATE TABLE vpn_servers
+(
+  id               SERIAL           NOT NULL PRIMARY KEY,
+  created_at       TIMESTAMPTZ      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at       TIMESTAMPTZ      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at       TIMESTAMPTZ               DEFAULT NULL,
+  last_seen_at     TIMESTAMPTZ      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  source           VARCHAR(64)      NOT NULL DEFAULT 'vpngate',
+  snapshot_id      INTEGER,
+  host_name        VARCHAR(255)     NOT NULL DEFAULT '',
+  ip               VARCHAR(45)      NOT NULL DEFAULT '',
+  score            BIGINT,
+  ping             INTEGER,
+  speed            BIGINT,
+  country_id       INTEGER,
+  num_vpn_sessions BIGINT,
+  uptime           BIGINT,
+  total_users      BIGINT,
+  total_traffic    BIGINT,
+  log_type         VARCHAR(255),
+  operator         VARCHAR(255),
+  message          VARCHAR(255),
+  open_vpn_config  TEXT,
+  protocol         VARCHAR(8)       NOT NULL DEFAULT '',
+  port             INTEGER          NOT NULL DEFAULT 0,
+  remote_hosts     VARCHAR(1024)    NOT NULL DEFAULT '',
+  cipher           VARCHAR(64)      NOT NULL DEFAULT '',
+  auth_digest      VARCHAR(64)      NOT NULL DEFAULT '',
+  compression      VARCHAR(32)      NOT NULL DEFAULT '',
+  embeds_ca        BOOLEAN          NOT NULL DEFAULT FALSE,
+  embeds_cert      BOOLEAN          NOT NULL DEFAULT FALSE,
+  embeds_key       BOOLEAN          NOT NULL DEFAULT FALSE,
+  latitude         DOUBLE PRECISION          DEFAULT NULL,
+  longitude        DOUBLE PRECISION          DEFAULT NULL,
+  reachable        BOOLEAN          NOT NULL DEFAULT FALSE,
+  probe_latency_ms INTEGER          NOT NULL DEFAULT 0,
+  probe_failures   INTEGER          NOT NULL DEFAULT 0,
+  probed_at        TIMESTAMPTZ               DEFAULT NULL
+);
+CREATE UNIQUE INDEX uid_vpn_servers_source_ip_host_name ON vpn_servers (source, ip, host_name);
+CREATE INDEX idx_vpn_servers_snapshot_id ON vpn_servers (snapshot_id);
+CREATE INDEX idx_vpn_servers_deleted_at ON vpn_servers (deleted_at);
+CREATE INDEX idx_vpn_servers_protocol_port ON vpn_servers (protocol, port);
+
+CREATE TABLE snapshots
+(
+  id         SERIAL      NOT NULL PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  source     VARCHAR(64) NOT NULL,
+  active     BOOLEAN     NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_snapshots_source_active ON snapshots (source, active);
+
+CREATE TABLE crawl_runs
+(
+  id            SERIAL      NOT NULL PRIMARY KEY,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  source        VARCHAR(64) NOT NULL,
+  status        VARCHAR(16) NOT NULL DEFAULT 'running',
+  started_at    TIMESTAMPTZ NOT NULL,
+  finished_at   TIMESTAMPTZ          DEFAULT NULL,
+  rows_fetched  INTEGER     NOT NULL DEFAULT 0,
+  rows_parsed   INTEGER     NOT NULL DEFAULT 0,
+  rows_rejected INTEGER     NOT NULL DEFAULT 0,
+  inserted      INTEGER     NOT NULL DEFAULT 0,
+  updated       INTEGER     NOT NULL DEFAULT 0,
+  removed       INTEGER     NOT NULL DEFAULT 0,
+  db_time_ms    INTEGER     NOT NULL DEFAULT 0,
+  error         TEXT        NOT NULL
+);
+CREATE INDEX idx_crawl_runs_source_started_at ON crawl_runs (source, started_at);
+
+CREATE TABLE leader_leases
+(
+  name       VARCHAR(64)  NOT NULL PRIMARY KEY,
+  created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  holder     VARCHAR(255) NOT NULL,
+  expires_at TIMESTAMPTZ  NOT NULL
+);
+
+CREATE TABLE server_metrics
+(
+  id               BIGSERIAL   NOT NULL PRIMARY KEY,
+  server_id        INTEGER     NOT NULL,
+  crawled_at       TIMESTAMPTZ NOT NULL,
+  score            BIGINT      NOT NULL DEFAULT 0,
+  ping             INTEGER     NOT NULL DEFAULT 0,
+  speed            BIGINT      NOT NULL DEFAULT 0,
+  num_vpn_sessions BIGINT      NOT NULL DEFAULT 0,
+  total_users      BIGINT      NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_server_metrics_server_id_crawled_at ON server_metrics (server_id, crawled_at);
+CREATE INDEX idx_server_metrics_crawled_at ON server_metrics (crawled_at);
+
+CREATE TABLE server_rules
+(
+  id                SERIAL       NOT NULL PRIMARY KEY,
+  created_at        TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at        TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  action            VARCHAR(8)   NOT NULL,
+  ip_cidr           VARCHAR(64)  NOT NULL DEFAULT '',
+  host_name_pattern VARCHAR(255) NOT NULL DEFAULT '',
+  operator_pattern  VARCHAR(255) NOT NULL DEFAULT '',
+  country_code      VARCHAR(2)   NOT NULL DEFAULT '',
+  comment           VARCHAR(255) NOT NULL DEFAULT '',
+  expires_at        TIMESTAMPTZ           DEFAULT NULL
+);
+
+-- PostgreSQL has no ON UPDATE CURRENT_TIMESTAMP, updated_at is refreshed by triggers instead
+CREATE FUNCTION set_updated_at() RETURNS TRIGGER AS
+$$
+BEGIN
+  NEW.updated_at = CURRENT_TIMESTAMP;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_countries_updated_at BEFORE UPDATE ON countries FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+CREATE TRIGGER trg_vpn_servers_updated_at BEFORE UPDATE ON vpn_servers FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+CREATE TRIGGER trg_snapshots_updated_at BEFORE UPDATE ON snapshots FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+CREATE TRIGGER trg_crawl_runs_updated_at BEFORE UPDATE ON crawl_runs FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+CREATE TRIGGER trg_leader_leases_updated_at BEFORE UPDATE ON leader_leases FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+CREATE TRIGGER trg_server_rules_updated_at BEFORE UPDATE ON server_rules FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
@@ -0,0 +1,4 @@
+DROP INDEX idx_vpn_servers_speed_id;
+DROP INDEX idx_vpn_servers_ping_id;
+DROP INDEX idx_vpn_servers_score_id;
+DROP INDEX idx_vpn_servers_num_vpn_sessions_id;
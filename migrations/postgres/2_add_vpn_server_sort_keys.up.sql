@@ -0,0 +1,4 @@
+CREATE INDEX idx_vpn_servers_speed_id ON vpn_servers (speed, id);
+CREATE INDEX idx_vpn_servers_ping_id ON vpn_servers (ping, id);
+CREATE INDEX idx_vpn_servers_score_id ON vpn_servers (score, id);
+CREATE INDEX idx_vpn_servers_num_vpn_sessions_id ON vpn_servers (num_vpn_sessions, id);
@@ -47,7 +47,7 @@ func (x VerifyAppleReceiptRequest_Environment) String() string {
 	return proto.EnumName(VerifyAppleReceiptRequest_Environment_name, int32(x))
 }
 func (VerifyAppleReceiptRequest_Environment) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{8, 0}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{29, 0}
 }
 
 // Country entity
@@ -67,7 +67,7 @@ func (m *Country) Reset()         { *m = Country{} }
 func (m *Country) String() string { return proto.CompactTextString(m) }
 func (*Country) ProtoMessage()    {}
 func (*Country) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{0}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{0}
 }
 func (m *Country) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Country.Unmarshal(m, b)
@@ -140,20 +140,58 @@ type VPNServer struct {
 	Message string `protobuf:"bytes,14,opt,name=message,proto3" json:"message,omitempty"`
 	// OpenVPN config
 	OpenVPNConfig string `protobuf:"bytes,15,opt,name=openVPNConfig,proto3" json:"openVPNConfig,omitempty"`
-	// created time
+	// created time, it is when the server was first seen
 	CreatedAt *timestamp.Timestamp `protobuf:"bytes,16,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	// updated time
-	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,17,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,17,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// name of the crawler source the server came from
+	Source string `protobuf:"bytes,18,opt,name=source,proto3" json:"source,omitempty"`
+	// last time the server was seen by the crawler
+	LastSeenAt *timestamp.Timestamp `protobuf:"bytes,19,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+	// OpenVPN protocol, tcp or udp
+	Protocol string `protobuf:"bytes,20,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// OpenVPN port
+	Port int32 `protobuf:"varint,21,opt,name=port,proto3" json:"port,omitempty"`
+	// OpenVPN remote hosts
+	RemoteHosts []string `protobuf:"bytes,22,rep,name=remoteHosts,proto3" json:"remoteHosts,omitempty"`
+	// OpenVPN data channel cipher
+	Cipher string `protobuf:"bytes,23,opt,name=cipher,proto3" json:"cipher,omitempty"`
+	// OpenVPN HMAC digest algorithm
+	AuthDigest string `protobuf:"bytes,24,opt,name=authDigest,proto3" json:"authDigest,omitempty"`
+	// OpenVPN compression algorithm, empty when compression is off
+	Compression string `protobuf:"bytes,25,opt,name=compression,proto3" json:"compression,omitempty"`
+	// whether the OpenVPN config embeds the CA certificate
+	EmbedsCA bool `protobuf:"varint,26,opt,name=embedsCA,proto3" json:"embedsCA,omitempty"`
+	// whether the OpenVPN config embeds a client certificate
+	EmbedsCert bool `protobuf:"varint,27,opt,name=embedsCert,proto3" json:"embedsCert,omitempty"`
+	// whether the OpenVPN config embeds a client key
+	EmbedsKey bool `protobuf:"varint,28,opt,name=embedsKey,proto3" json:"embedsKey,omitempty"`
+	// whether the last probe from our infrastructure reached the OpenVPN port
+	Reachable bool `protobuf:"varint,29,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// latency of the last probe in milliseconds
+	ProbeLatencyMs int32 `protobuf:"varint,30,opt,name=probeLatencyMs,proto3" json:"probeLatencyMs,omitempty"`
+	// number of consecutive failed probes
+	ProbeFailures int32 `protobuf:"varint,31,opt,name=probeFailures,proto3" json:"probeFailures,omitempty"`
+	// last time the server was probed
+	ProbedAt *timestamp.Timestamp `protobuf:"bytes,32,opt,name=probedAt,proto3" json:"probedAt,omitempty"`
+	// ranker score between 0 and 1, only set when the list is ranked
+	Rank float64 `protobuf:"fixed64,33,opt,name=rank,proto3" json:"rank,omitempty"`
+	// latitude of the server location, 0 when it is unknown
+	Latitude float64 `protobuf:"fixed64,34,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// longitude of the server location, 0 when it is unknown
+	Longitude float64 `protobuf:"fixed64,35,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// distance to the client in kilometers, only set by ListNearestServers
+	DistanceKm           float64  `protobuf:"fixed64,36,opt,name=distanceKm,proto3" json:"distanceKm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *VPNServer) Reset()         { *m = VPNServer{} }
 func (m *VPNServer) String() string { return proto.CompactTextString(m) }
 func (*VPNServer) ProtoMessage()    {}
 func (*VPNServer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{1}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{1}
 }
 func (m *VPNServer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VPNServer.Unmarshal(m, b)
@@ -292,6 +330,139 @@ func (m *VPNServer) GetUpdatedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *VPNServer) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *VPNServer) GetLastSeenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
+}
+
+func (m *VPNServer) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *VPNServer) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *VPNServer) GetRemoteHosts() []string {
+	if m != nil {
+		return m.RemoteHosts
+	}
+	return nil
+}
+
+func (m *VPNServer) GetCipher() string {
+	if m != nil {
+		return m.Cipher
+	}
+	return ""
+}
+
+func (m *VPNServer) GetAuthDigest() string {
+	if m != nil {
+		return m.AuthDigest
+	}
+	return ""
+}
+
+func (m *VPNServer) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *VPNServer) GetEmbedsCA() bool {
+	if m != nil {
+		return m.EmbedsCA
+	}
+	return false
+}
+
+func (m *VPNServer) GetEmbedsCert() bool {
+	if m != nil {
+		return m.EmbedsCert
+	}
+	return false
+}
+
+func (m *VPNServer) GetEmbedsKey() bool {
+	if m != nil {
+		return m.EmbedsKey
+	}
+	return false
+}
+
+func (m *VPNServer) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+func (m *VPNServer) GetProbeLatencyMs() int32 {
+	if m != nil {
+		return m.ProbeLatencyMs
+	}
+	return 0
+}
+
+func (m *VPNServer) GetProbeFailures() int32 {
+	if m != nil {
+		return m.ProbeFailures
+	}
+	return 0
+}
+
+func (m *VPNServer) GetProbedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ProbedAt
+	}
+	return nil
+}
+
+func (m *VPNServer) GetRank() float64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *VPNServer) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *VPNServer) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *VPNServer) GetDistanceKm() float64 {
+	if m != nil {
+		return m.DistanceKm
+	}
+	return 0
+}
+
 // List country request
 type ListCountriesRequest struct {
 	// api version
@@ -305,7 +476,7 @@ func (m *ListCountriesRequest) Reset()         { *m = ListCountriesRequest{} }
 func (m *ListCountriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListCountriesRequest) ProtoMessage()    {}
 func (*ListCountriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{2}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{2}
 }
 func (m *ListCountriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListCountriesRequest.Unmarshal(m, b)
@@ -347,7 +518,7 @@ func (m *ListCountriesResponse) Reset()         { *m = ListCountriesResponse{} }
 func (m *ListCountriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListCountriesResponse) ProtoMessage()    {}
 func (*ListCountriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{3}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{3}
 }
 func (m *ListCountriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListCountriesResponse.Unmarshal(m, b)
@@ -386,7 +557,30 @@ type ListVPNServerRequest struct {
 	// api version
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	// country code
-	CountryCode          string   `protobuf:"bytes,2,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	CountryCode string `protobuf:"bytes,2,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	// include servers that failed too many consecutive probes
+	IncludeUnreachable bool `protobuf:"varint,3,opt,name=includeUnreachable,proto3" json:"includeUnreachable,omitempty"`
+	// order of the list, speed (default) or rank. Lists ordered by rank are not paginated, they hold
+	// the pageSize best ranked servers, use sortBy and order for paginated lists
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// number of servers of a page, defaults to 50 and is at most 200
+	PageSize uint32 `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// nextPageToken of the previous page, the first page when it is empty
+	PageToken string `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// least speed of the listed servers
+	MinSpeed int64 `protobuf:"varint,7,opt,name=minSpeed,proto3" json:"minSpeed,omitempty"`
+	// highest ping of the listed servers
+	MaxPing int32 `protobuf:"varint,8,opt,name=maxPing,proto3" json:"maxPing,omitempty"`
+	// highest number of VPN sessions of the listed servers
+	MaxSessions int32 `protobuf:"varint,9,opt,name=maxSessions,proto3" json:"maxSessions,omitempty"`
+	// OpenVPN protocol of the listed servers, tcp or udp
+	Protocol string `protobuf:"bytes,10,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// operator of the listed servers
+	Operator string `protobuf:"bytes,11,opt,name=operator,proto3" json:"operator,omitempty"`
+	// sort key of the list: speed (default), ping, score or sessions
+	SortBy string `protobuf:"bytes,12,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	// asc or desc, defaults to the best servers first: fastest, lowest ping, highest score, fewest sessions
+	Order                string   `protobuf:"bytes,13,opt,name=order,proto3" json:"order,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -396,7 +590,7 @@ func (m *ListVPNServerRequest) Reset()         { *m = ListVPNServerRequest{} }
 func (m *ListVPNServerRequest) String() string { return proto.CompactTextString(m) }
 func (*ListVPNServerRequest) ProtoMessage()    {}
 func (*ListVPNServerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{4}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{4}
 }
 func (m *ListVPNServerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListVPNServerRequest.Unmarshal(m, b)
@@ -430,22 +624,103 @@ func (m *ListVPNServerRequest) GetCountryCode() string {
 	return ""
 }
 
+func (m *ListVPNServerRequest) GetIncludeUnreachable() bool {
+	if m != nil {
+		return m.IncludeUnreachable
+	}
+	return false
+}
+
+func (m *ListVPNServerRequest) GetSort() string {
+	if m != nil {
+		return m.Sort
+	}
+	return ""
+}
+
+func (m *ListVPNServerRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListVPNServerRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListVPNServerRequest) GetMinSpeed() int64 {
+	if m != nil {
+		return m.MinSpeed
+	}
+	return 0
+}
+
+func (m *ListVPNServerRequest) GetMaxPing() int32 {
+	if m != nil {
+		return m.MaxPing
+	}
+	return 0
+}
+
+func (m *ListVPNServerRequest) GetMaxSessions() int32 {
+	if m != nil {
+		return m.MaxSessions
+	}
+	return 0
+}
+
+func (m *ListVPNServerRequest) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *ListVPNServerRequest) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *ListVPNServerRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+func (m *ListVPNServerRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
 // List VPN servers response {
 type ListVPNServerResponse struct {
 	// api version
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	// list VPN servers
-	Data                 []*VPNServer `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Data []*VPNServer `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// number of servers matching the filters across every page
+	TotalCount           int64    `protobuf:"varint,4,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListVPNServerResponse) Reset()         { *m = ListVPNServerResponse{} }
 func (m *ListVPNServerResponse) String() string { return proto.CompactTextString(m) }
 func (*ListVPNServerResponse) ProtoMessage()    {}
 func (*ListVPNServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{5}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{5}
 }
 func (m *ListVPNServerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListVPNServerResponse.Unmarshal(m, b)
@@ -459,115 +734,1501 @@ func (dst *ListVPNServerResponse) XXX_Merge(src proto.Message) {
 func (m *ListVPNServerResponse) XXX_Size() int {
 	return xxx_messageInfo_ListVPNServerResponse.Size(m)
 }
-func (m *ListVPNServerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListVPNServerResponse.DiscardUnknown(m)
+func (m *ListVPNServerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVPNServerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVPNServerResponse proto.InternalMessageInfo
+
+func (m *ListVPNServerResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListVPNServerResponse) GetData() []*VPNServer {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ListVPNServerResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *ListVPNServerResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+// List recommended VPN servers request
+type ListRecommendedServersRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// country code, all countries when it is empty
+	CountryCode string `protobuf:"bytes,2,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	// number of servers, defaults to 10
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRecommendedServersRequest) Reset()         { *m = ListRecommendedServersRequest{} }
+func (m *ListRecommendedServersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRecommendedServersRequest) ProtoMessage()    {}
+func (*ListRecommendedServersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{6}
+}
+func (m *ListRecommendedServersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRecommendedServersRequest.Unmarshal(m, b)
+}
+func (m *ListRecommendedServersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRecommendedServersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRecommendedServersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecommendedServersRequest.Merge(dst, src)
+}
+func (m *ListRecommendedServersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRecommendedServersRequest.Size(m)
+}
+func (m *ListRecommendedServersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecommendedServersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecommendedServersRequest proto.InternalMessageInfo
+
+func (m *ListRecommendedServersRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListRecommendedServersRequest) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *ListRecommendedServersRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// List recommended VPN servers response
+type ListRecommendedServersResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// best ranked VPN servers first
+	Data                 []*VPNServer `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListRecommendedServersResponse) Reset()         { *m = ListRecommendedServersResponse{} }
+func (m *ListRecommendedServersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRecommendedServersResponse) ProtoMessage()    {}
+func (*ListRecommendedServersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{7}
+}
+func (m *ListRecommendedServersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRecommendedServersResponse.Unmarshal(m, b)
+}
+func (m *ListRecommendedServersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRecommendedServersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListRecommendedServersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecommendedServersResponse.Merge(dst, src)
+}
+func (m *ListRecommendedServersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRecommendedServersResponse.Size(m)
+}
+func (m *ListRecommendedServersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecommendedServersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecommendedServersResponse proto.InternalMessageInfo
+
+func (m *ListRecommendedServersResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListRecommendedServersResponse) GetData() []*VPNServer {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// List nearest VPN servers request
+type ListNearestServersRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// number of servers, defaults to 10
+	Limit                uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNearestServersRequest) Reset()         { *m = ListNearestServersRequest{} }
+func (m *ListNearestServersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNearestServersRequest) ProtoMessage()    {}
+func (*ListNearestServersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{8}
+}
+func (m *ListNearestServersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNearestServersRequest.Unmarshal(m, b)
+}
+func (m *ListNearestServersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNearestServersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListNearestServersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNearestServersRequest.Merge(dst, src)
+}
+func (m *ListNearestServersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNearestServersRequest.Size(m)
+}
+func (m *ListNearestServersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNearestServersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNearestServersRequest proto.InternalMessageInfo
+
+func (m *ListNearestServersRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListNearestServersRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// List nearest VPN servers response
+type ListNearestServersResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// country code of the client location, empty when the client could not be located
+	CountryCode string `protobuf:"bytes,2,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	// nearest VPN servers first, best ranked first among servers at a similar distance
+	Data                 []*VPNServer `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListNearestServersResponse) Reset()         { *m = ListNearestServersResponse{} }
+func (m *ListNearestServersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNearestServersResponse) ProtoMessage()    {}
+func (*ListNearestServersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{9}
+}
+func (m *ListNearestServersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNearestServersResponse.Unmarshal(m, b)
+}
+func (m *ListNearestServersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNearestServersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListNearestServersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNearestServersResponse.Merge(dst, src)
+}
+func (m *ListNearestServersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNearestServersResponse.Size(m)
+}
+func (m *ListNearestServersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNearestServersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNearestServersResponse proto.InternalMessageInfo
+
+func (m *ListNearestServersResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListNearestServersResponse) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *ListNearestServersResponse) GetData() []*VPNServer {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// VPNGateCrawler request
+type VPNGateCrawlerRequest struct {
+	// api version
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VPNGateCrawlerRequest) Reset()         { *m = VPNGateCrawlerRequest{} }
+func (m *VPNGateCrawlerRequest) String() string { return proto.CompactTextString(m) }
+func (*VPNGateCrawlerRequest) ProtoMessage()    {}
+func (*VPNGateCrawlerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{10}
+}
+func (m *VPNGateCrawlerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VPNGateCrawlerRequest.Unmarshal(m, b)
+}
+func (m *VPNGateCrawlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VPNGateCrawlerRequest.Marshal(b, m, deterministic)
+}
+func (dst *VPNGateCrawlerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VPNGateCrawlerRequest.Merge(dst, src)
+}
+func (m *VPNGateCrawlerRequest) XXX_Size() int {
+	return xxx_messageInfo_VPNGateCrawlerRequest.Size(m)
+}
+func (m *VPNGateCrawlerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VPNGateCrawlerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VPNGateCrawlerRequest proto.InternalMessageInfo
+
+func (m *VPNGateCrawlerRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+// VPNGateCrawler response
+type VPNGateCrawlerResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// list vpn servers
+	Data                 []*VPNServer `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *VPNGateCrawlerResponse) Reset()         { *m = VPNGateCrawlerResponse{} }
+func (m *VPNGateCrawlerResponse) String() string { return proto.CompactTextString(m) }
+func (*VPNGateCrawlerResponse) ProtoMessage()    {}
+func (*VPNGateCrawlerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{11}
+}
+func (m *VPNGateCrawlerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VPNGateCrawlerResponse.Unmarshal(m, b)
+}
+func (m *VPNGateCrawlerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VPNGateCrawlerResponse.Marshal(b, m, deterministic)
+}
+func (dst *VPNGateCrawlerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VPNGateCrawlerResponse.Merge(dst, src)
+}
+func (m *VPNGateCrawlerResponse) XXX_Size() int {
+	return xxx_messageInfo_VPNGateCrawlerResponse.Size(m)
+}
+func (m *VPNGateCrawlerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VPNGateCrawlerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VPNGateCrawlerResponse proto.InternalMessageInfo
+
+func (m *VPNGateCrawlerResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *VPNGateCrawlerResponse) GetData() []*VPNServer {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Get OpenVPN profile request
+type GetOpenVPNProfileRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// VPN server id
+	Id int32 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// client side directives added to the profile e.g. auth-nocache
+	Directives []string `protobuf:"bytes,4,rep,name=directives,proto3" json:"directives,omitempty"`
+	// DNS servers added to the profile
+	Dns                  []string `protobuf:"bytes,5,rep,name=dns,proto3" json:"dns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOpenVPNProfileRequest) Reset()         { *m = GetOpenVPNProfileRequest{} }
+func (m *GetOpenVPNProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOpenVPNProfileRequest) ProtoMessage()    {}
+func (*GetOpenVPNProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{12}
+}
+func (m *GetOpenVPNProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOpenVPNProfileRequest.Unmarshal(m, b)
+}
+func (m *GetOpenVPNProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOpenVPNProfileRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetOpenVPNProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOpenVPNProfileRequest.Merge(dst, src)
+}
+func (m *GetOpenVPNProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOpenVPNProfileRequest.Size(m)
+}
+func (m *GetOpenVPNProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOpenVPNProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOpenVPNProfileRequest proto.InternalMessageInfo
+
+func (m *GetOpenVPNProfileRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetOpenVPNProfileRequest) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetOpenVPNProfileRequest) GetDirectives() []string {
+	if m != nil {
+		return m.Directives
+	}
+	return nil
+}
+
+func (m *GetOpenVPNProfileRequest) GetDns() []string {
+	if m != nil {
+		return m.Dns
+	}
+	return nil
+}
+
+// Get OpenVPN profile response
+type GetOpenVPNProfileResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// file name of the profile
+	FileName string `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	// content type of the profile
+	ContentType string `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// decoded OpenVPN profile
+	Content              []byte   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOpenVPNProfileResponse) Reset()         { *m = GetOpenVPNProfileResponse{} }
+func (m *GetOpenVPNProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOpenVPNProfileResponse) ProtoMessage()    {}
+func (*GetOpenVPNProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{13}
+}
+func (m *GetOpenVPNProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOpenVPNProfileResponse.Unmarshal(m, b)
+}
+func (m *GetOpenVPNProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOpenVPNProfileResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetOpenVPNProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOpenVPNProfileResponse.Merge(dst, src)
+}
+func (m *GetOpenVPNProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_GetOpenVPNProfileResponse.Size(m)
+}
+func (m *GetOpenVPNProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOpenVPNProfileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOpenVPNProfileResponse proto.InternalMessageInfo
+
+func (m *GetOpenVPNProfileResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetOpenVPNProfileResponse) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *GetOpenVPNProfileResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *GetOpenVPNProfileResponse) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+// Metric point of a VPN server, values are averages over the point time bucket
+type MetricPoint struct {
+	// start of the time bucket
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// average score
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// average ping
+	Ping float64 `protobuf:"fixed64,3,opt,name=ping,proto3" json:"ping,omitempty"`
+	// average speed
+	Speed float64 `protobuf:"fixed64,4,opt,name=speed,proto3" json:"speed,omitempty"`
+	// average number of VPN sessions
+	NumVPNSessions float64 `protobuf:"fixed64,5,opt,name=numVPNSessions,proto3" json:"numVPNSessions,omitempty"`
+	// average total of users
+	TotalUsers float64 `protobuf:"fixed64,6,opt,name=totalUsers,proto3" json:"totalUsers,omitempty"`
+	// number of crawled points averaged
+	Samples              int32    `protobuf:"varint,7,opt,name=samples,proto3" json:"samples,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetricPoint) Reset()         { *m = MetricPoint{} }
+func (m *MetricPoint) String() string { return proto.CompactTextString(m) }
+func (*MetricPoint) ProtoMessage()    {}
+func (*MetricPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{14}
+}
+func (m *MetricPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricPoint.Unmarshal(m, b)
+}
+func (m *MetricPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricPoint.Marshal(b, m, deterministic)
+}
+func (dst *MetricPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricPoint.Merge(dst, src)
+}
+func (m *MetricPoint) XXX_Size() int {
+	return xxx_messageInfo_MetricPoint.Size(m)
+}
+func (m *MetricPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricPoint proto.InternalMessageInfo
+
+func (m *MetricPoint) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *MetricPoint) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *MetricPoint) GetPing() float64 {
+	if m != nil {
+		return m.Ping
+	}
+	return 0
+}
+
+func (m *MetricPoint) GetSpeed() float64 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+func (m *MetricPoint) GetNumVPNSessions() float64 {
+	if m != nil {
+		return m.NumVPNSessions
+	}
+	return 0
+}
+
+func (m *MetricPoint) GetTotalUsers() float64 {
+	if m != nil {
+		return m.TotalUsers
+	}
+	return 0
+}
+
+func (m *MetricPoint) GetSamples() int32 {
+	if m != nil {
+		return m.Samples
+	}
+	return 0
+}
+
+// Get server metrics request
+type GetServerMetricsRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// VPN server id
+	Id int32 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// start of the time range, defaults to 24 hours before the end
+	From *timestamp.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// end of the time range, defaults to now
+	To *timestamp.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// maximum number of points of the downsampled series, defaults to 100
+	MaxPoints            uint32   `protobuf:"varint,5,opt,name=maxPoints,proto3" json:"maxPoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServerMetricsRequest) Reset()         { *m = GetServerMetricsRequest{} }
+func (m *GetServerMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServerMetricsRequest) ProtoMessage()    {}
+func (*GetServerMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{15}
+}
+func (m *GetServerMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServerMetricsRequest.Unmarshal(m, b)
+}
+func (m *GetServerMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServerMetricsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetServerMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServerMetricsRequest.Merge(dst, src)
+}
+func (m *GetServerMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServerMetricsRequest.Size(m)
+}
+func (m *GetServerMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServerMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServerMetricsRequest proto.InternalMessageInfo
+
+func (m *GetServerMetricsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetServerMetricsRequest) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetServerMetricsRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *GetServerMetricsRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *GetServerMetricsRequest) GetMaxPoints() uint32 {
+	if m != nil {
+		return m.MaxPoints
+	}
+	return 0
+}
+
+// Get server metrics response
+type GetServerMetricsResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// downsampled metric series
+	Data []*MetricPoint `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	// average over the whole time range
+	Average              *MetricPoint `protobuf:"bytes,3,opt,name=average,proto3" json:"average,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetServerMetricsResponse) Reset()         { *m = GetServerMetricsResponse{} }
+func (m *GetServerMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServerMetricsResponse) ProtoMessage()    {}
+func (*GetServerMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{16}
+}
+func (m *GetServerMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServerMetricsResponse.Unmarshal(m, b)
+}
+func (m *GetServerMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServerMetricsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetServerMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServerMetricsResponse.Merge(dst, src)
+}
+func (m *GetServerMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetServerMetricsResponse.Size(m)
+}
+func (m *GetServerMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServerMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServerMetricsResponse proto.InternalMessageInfo
+
+func (m *GetServerMetricsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetServerMetricsResponse) GetData() []*MetricPoint {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetServerMetricsResponse) GetAverage() *MetricPoint {
+	if m != nil {
+		return m.Average
+	}
+	return nil
+}
+
+// CrawlRun entity
+type CrawlRun struct {
+	// unique id
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// crawler source name
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// start time
+	StartedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	// finish time, it is empty while the crawl is running
+	FinishedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
+	// number of rows fetched from the source
+	RowsFetched int32 `protobuf:"varint,5,opt,name=rowsFetched,proto3" json:"rowsFetched,omitempty"`
+	// number of rows parsed successfully
+	RowsParsed int32 `protobuf:"varint,6,opt,name=rowsParsed,proto3" json:"rowsParsed,omitempty"`
+	// number of rows rejected by the parser
+	RowsRejected int32 `protobuf:"varint,7,opt,name=rowsRejected,proto3" json:"rowsRejected,omitempty"`
+	// number of inserted VPN servers
+	Inserted int32 `protobuf:"varint,8,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	// number of updated VPN servers
+	Updated int32 `protobuf:"varint,9,opt,name=updated,proto3" json:"updated,omitempty"`
+	// number of removed VPN servers
+	Removed int32 `protobuf:"varint,10,opt,name=removed,proto3" json:"removed,omitempty"`
+	// error of a failed crawl
+	Error string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	// time spent persisting the crawl in milliseconds
+	DbTimeMs int32 `protobuf:"varint,12,opt,name=dbTimeMs,proto3" json:"dbTimeMs,omitempty"`
+	// running, succeeded, failed, rejected by the crawl guard or skipped when the feed did not change
+	Status               string   `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrawlRun) Reset()         { *m = CrawlRun{} }
+func (m *CrawlRun) String() string { return proto.CompactTextString(m) }
+func (*CrawlRun) ProtoMessage()    {}
+func (*CrawlRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{17}
+}
+func (m *CrawlRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrawlRun.Unmarshal(m, b)
+}
+func (m *CrawlRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrawlRun.Marshal(b, m, deterministic)
+}
+func (dst *CrawlRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrawlRun.Merge(dst, src)
+}
+func (m *CrawlRun) XXX_Size() int {
+	return xxx_messageInfo_CrawlRun.Size(m)
+}
+func (m *CrawlRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrawlRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrawlRun proto.InternalMessageInfo
+
+func (m *CrawlRun) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CrawlRun) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *CrawlRun) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *CrawlRun) GetRowsFetched() int32 {
+	if m != nil {
+		return m.RowsFetched
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetRowsParsed() int32 {
+	if m != nil {
+		return m.RowsParsed
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetRowsRejected() int32 {
+	if m != nil {
+		return m.RowsRejected
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetInserted() int32 {
+	if m != nil {
+		return m.Inserted
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetUpdated() int32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CrawlRun) GetDbTimeMs() int32 {
+	if m != nil {
+		return m.DbTimeMs
+	}
+	return 0
+}
+
+func (m *CrawlRun) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+// List crawl runs request
+type ListCrawlRunsRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// crawler source name, all sources when empty
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// maximum number of runs, defaults to 50
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCrawlRunsRequest) Reset()         { *m = ListCrawlRunsRequest{} }
+func (m *ListCrawlRunsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCrawlRunsRequest) ProtoMessage()    {}
+func (*ListCrawlRunsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{18}
+}
+func (m *ListCrawlRunsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCrawlRunsRequest.Unmarshal(m, b)
+}
+func (m *ListCrawlRunsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCrawlRunsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListCrawlRunsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCrawlRunsRequest.Merge(dst, src)
+}
+func (m *ListCrawlRunsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCrawlRunsRequest.Size(m)
+}
+func (m *ListCrawlRunsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCrawlRunsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCrawlRunsRequest proto.InternalMessageInfo
+
+func (m *ListCrawlRunsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCrawlRunsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ListCrawlRunsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// List crawl runs response
+type ListCrawlRunsResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// list crawl runs, latest first
+	Data                 []*CrawlRun `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListCrawlRunsResponse) Reset()         { *m = ListCrawlRunsResponse{} }
+func (m *ListCrawlRunsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCrawlRunsResponse) ProtoMessage()    {}
+func (*ListCrawlRunsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{19}
+}
+func (m *ListCrawlRunsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCrawlRunsResponse.Unmarshal(m, b)
+}
+func (m *ListCrawlRunsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCrawlRunsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListCrawlRunsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCrawlRunsResponse.Merge(dst, src)
+}
+func (m *ListCrawlRunsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCrawlRunsResponse.Size(m)
+}
+func (m *ListCrawlRunsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCrawlRunsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCrawlRunsResponse proto.InternalMessageInfo
+
+func (m *ListCrawlRunsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCrawlRunsResponse) GetData() []*CrawlRun {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Get crawl run request
+type GetCrawlRunRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// crawl run id
+	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCrawlRunRequest) Reset()         { *m = GetCrawlRunRequest{} }
+func (m *GetCrawlRunRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCrawlRunRequest) ProtoMessage()    {}
+func (*GetCrawlRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{20}
+}
+func (m *GetCrawlRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCrawlRunRequest.Unmarshal(m, b)
+}
+func (m *GetCrawlRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCrawlRunRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetCrawlRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCrawlRunRequest.Merge(dst, src)
+}
+func (m *GetCrawlRunRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCrawlRunRequest.Size(m)
+}
+func (m *GetCrawlRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCrawlRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCrawlRunRequest proto.InternalMessageInfo
+
+func (m *GetCrawlRunRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetCrawlRunRequest) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// Get crawl run response
+type GetCrawlRunResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// crawl run
+	Data                 *CrawlRun `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetCrawlRunResponse) Reset()         { *m = GetCrawlRunResponse{} }
+func (m *GetCrawlRunResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCrawlRunResponse) ProtoMessage()    {}
+func (*GetCrawlRunResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{21}
+}
+func (m *GetCrawlRunResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCrawlRunResponse.Unmarshal(m, b)
+}
+func (m *GetCrawlRunResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCrawlRunResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetCrawlRunResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCrawlRunResponse.Merge(dst, src)
+}
+func (m *GetCrawlRunResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCrawlRunResponse.Size(m)
+}
+func (m *GetCrawlRunResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCrawlRunResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCrawlRunResponse proto.InternalMessageInfo
+
+func (m *GetCrawlRunResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetCrawlRunResponse) GetData() *CrawlRun {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// ServerRule entity allows or denies the VPN servers matching all of its non empty criteria,
+// allow rules win over deny rules
+type ServerRule struct {
+	// unique id
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// allow or deny
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// single IP address or CIDR
+	IpCidr string `protobuf:"bytes,3,opt,name=ipCidr,proto3" json:"ipCidr,omitempty"`
+	// case insensitive glob pattern of host names e.g. public-vpn-*
+	HostNamePattern string `protobuf:"bytes,4,opt,name=hostNamePattern,proto3" json:"hostNamePattern,omitempty"`
+	// case insensitive glob pattern of operators
+	OperatorPattern string `protobuf:"bytes,5,opt,name=operatorPattern,proto3" json:"operatorPattern,omitempty"`
+	// country code
+	CountryCode string `protobuf:"bytes,6,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	// why the rule exists
+	Comment string `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	// time the rule stops applying, it never expires when empty
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// created time
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ServerRule) Reset()         { *m = ServerRule{} }
+func (m *ServerRule) String() string { return proto.CompactTextString(m) }
+func (*ServerRule) ProtoMessage()    {}
+func (*ServerRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{22}
+}
+func (m *ServerRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerRule.Unmarshal(m, b)
+}
+func (m *ServerRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerRule.Marshal(b, m, deterministic)
+}
+func (dst *ServerRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerRule.Merge(dst, src)
+}
+func (m *ServerRule) XXX_Size() int {
+	return xxx_messageInfo_ServerRule.Size(m)
+}
+func (m *ServerRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerRule proto.InternalMessageInfo
+
+func (m *ServerRule) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ServerRule) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ServerRule) GetIpCidr() string {
+	if m != nil {
+		return m.IpCidr
+	}
+	return ""
+}
+
+func (m *ServerRule) GetHostNamePattern() string {
+	if m != nil {
+		return m.HostNamePattern
+	}
+	return ""
+}
+
+func (m *ServerRule) GetOperatorPattern() string {
+	if m != nil {
+		return m.OperatorPattern
+	}
+	return ""
+}
+
+func (m *ServerRule) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *ServerRule) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *ServerRule) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *ServerRule) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+// Create server rule request
+type CreateServerRuleRequest struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// allow or deny
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// single IP address or CIDR
+	IpCidr string `protobuf:"bytes,3,opt,name=ipCidr,proto3" json:"ipCidr,omitempty"`
+	// case insensitive glob pattern of host names
+	HostNamePattern string `protobuf:"bytes,4,opt,name=hostNamePattern,proto3" json:"hostNamePattern,omitempty"`
+	// case insensitive glob pattern of operators
+	OperatorPattern string `protobuf:"bytes,5,opt,name=operatorPattern,proto3" json:"operatorPattern,omitempty"`
+	// country code
+	CountryCode string `protobuf:"bytes,6,opt,name=countryCode,proto3" json:"countryCode,omitempty"`
+	// why the rule exists
+	Comment string `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	// time the rule stops applying, it never expires when empty
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateServerRuleRequest) Reset()         { *m = CreateServerRuleRequest{} }
+func (m *CreateServerRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateServerRuleRequest) ProtoMessage()    {}
+func (*CreateServerRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{23}
+}
+func (m *CreateServerRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateServerRuleRequest.Unmarshal(m, b)
+}
+func (m *CreateServerRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateServerRuleRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateServerRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateServerRuleRequest.Merge(dst, src)
+}
+func (m *CreateServerRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateServerRuleRequest.Size(m)
+}
+func (m *CreateServerRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateServerRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateServerRuleRequest proto.InternalMessageInfo
+
+func (m *CreateServerRuleRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetIpCidr() string {
+	if m != nil {
+		return m.IpCidr
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetHostNamePattern() string {
+	if m != nil {
+		return m.HostNamePattern
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetOperatorPattern() string {
+	if m != nil {
+		return m.OperatorPattern
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *CreateServerRuleRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+// Create server rule response
+type CreateServerRuleResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// created server rule
+	Data                 *ServerRule `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateServerRuleResponse) Reset()         { *m = CreateServerRuleResponse{} }
+func (m *CreateServerRuleResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateServerRuleResponse) ProtoMessage()    {}
+func (*CreateServerRuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{24}
+}
+func (m *CreateServerRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateServerRuleResponse.Unmarshal(m, b)
+}
+func (m *CreateServerRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateServerRuleResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateServerRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateServerRuleResponse.Merge(dst, src)
+}
+func (m *CreateServerRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateServerRuleResponse.Size(m)
+}
+func (m *CreateServerRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateServerRuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateServerRuleResponse proto.InternalMessageInfo
+
+func (m *CreateServerRuleResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateServerRuleResponse) GetData() *ServerRule {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// List server rules request
+type ListServerRulesRequest struct {
+	// api version
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListServerRulesRequest) Reset()         { *m = ListServerRulesRequest{} }
+func (m *ListServerRulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListServerRulesRequest) ProtoMessage()    {}
+func (*ListServerRulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{25}
+}
+func (m *ListServerRulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServerRulesRequest.Unmarshal(m, b)
+}
+func (m *ListServerRulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServerRulesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListServerRulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServerRulesRequest.Merge(dst, src)
+}
+func (m *ListServerRulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListServerRulesRequest.Size(m)
+}
+func (m *ListServerRulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServerRulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServerRulesRequest proto.InternalMessageInfo
+
+func (m *ListServerRulesRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+// List server rules response
+type ListServerRulesResponse struct {
+	// api version
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// server rules, expired ones included
+	Data                 []*ServerRule `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListServerRulesResponse) Reset()         { *m = ListServerRulesResponse{} }
+func (m *ListServerRulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListServerRulesResponse) ProtoMessage()    {}
+func (*ListServerRulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{26}
+}
+func (m *ListServerRulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServerRulesResponse.Unmarshal(m, b)
+}
+func (m *ListServerRulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServerRulesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListServerRulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServerRulesResponse.Merge(dst, src)
+}
+func (m *ListServerRulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListServerRulesResponse.Size(m)
+}
+func (m *ListServerRulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServerRulesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListVPNServerResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListServerRulesResponse proto.InternalMessageInfo
 
-func (m *ListVPNServerResponse) GetApi() string {
+func (m *ListServerRulesResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *ListVPNServerResponse) GetData() []*VPNServer {
+func (m *ListServerRulesResponse) GetData() []*ServerRule {
 	if m != nil {
 		return m.Data
 	}
 	return nil
 }
 
-// VPNGateCrawler request
-type VPNGateCrawlerRequest struct {
+// Delete server rule request
+type DeleteServerRuleRequest struct {
 	// api version
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// server rule id
+	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *VPNGateCrawlerRequest) Reset()         { *m = VPNGateCrawlerRequest{} }
-func (m *VPNGateCrawlerRequest) String() string { return proto.CompactTextString(m) }
-func (*VPNGateCrawlerRequest) ProtoMessage()    {}
-func (*VPNGateCrawlerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{6}
+func (m *DeleteServerRuleRequest) Reset()         { *m = DeleteServerRuleRequest{} }
+func (m *DeleteServerRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteServerRuleRequest) ProtoMessage()    {}
+func (*DeleteServerRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{27}
 }
-func (m *VPNGateCrawlerRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_VPNGateCrawlerRequest.Unmarshal(m, b)
+func (m *DeleteServerRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteServerRuleRequest.Unmarshal(m, b)
 }
-func (m *VPNGateCrawlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_VPNGateCrawlerRequest.Marshal(b, m, deterministic)
+func (m *DeleteServerRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteServerRuleRequest.Marshal(b, m, deterministic)
 }
-func (dst *VPNGateCrawlerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VPNGateCrawlerRequest.Merge(dst, src)
+func (dst *DeleteServerRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteServerRuleRequest.Merge(dst, src)
 }
-func (m *VPNGateCrawlerRequest) XXX_Size() int {
-	return xxx_messageInfo_VPNGateCrawlerRequest.Size(m)
+func (m *DeleteServerRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteServerRuleRequest.Size(m)
 }
-func (m *VPNGateCrawlerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_VPNGateCrawlerRequest.DiscardUnknown(m)
+func (m *DeleteServerRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteServerRuleRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VPNGateCrawlerRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteServerRuleRequest proto.InternalMessageInfo
 
-func (m *VPNGateCrawlerRequest) GetApi() string {
+func (m *DeleteServerRuleRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-// VPNGateCrawler response
-type VPNGateCrawlerResponse struct {
+func (m *DeleteServerRuleRequest) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// Delete server rule response
+type DeleteServerRuleResponse struct {
 	// api version
-	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	// list vpn servers
-	Data                 []*VPNServer `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *VPNGateCrawlerResponse) Reset()         { *m = VPNGateCrawlerResponse{} }
-func (m *VPNGateCrawlerResponse) String() string { return proto.CompactTextString(m) }
-func (*VPNGateCrawlerResponse) ProtoMessage()    {}
-func (*VPNGateCrawlerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{7}
+func (m *DeleteServerRuleResponse) Reset()         { *m = DeleteServerRuleResponse{} }
+func (m *DeleteServerRuleResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteServerRuleResponse) ProtoMessage()    {}
+func (*DeleteServerRuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{28}
 }
-func (m *VPNGateCrawlerResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_VPNGateCrawlerResponse.Unmarshal(m, b)
+func (m *DeleteServerRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteServerRuleResponse.Unmarshal(m, b)
 }
-func (m *VPNGateCrawlerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_VPNGateCrawlerResponse.Marshal(b, m, deterministic)
+func (m *DeleteServerRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteServerRuleResponse.Marshal(b, m, deterministic)
 }
-func (dst *VPNGateCrawlerResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VPNGateCrawlerResponse.Merge(dst, src)
+func (dst *DeleteServerRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteServerRuleResponse.Merge(dst, src)
 }
-func (m *VPNGateCrawlerResponse) XXX_Size() int {
-	return xxx_messageInfo_VPNGateCrawlerResponse.Size(m)
+func (m *DeleteServerRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteServerRuleResponse.Size(m)
 }
-func (m *VPNGateCrawlerResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_VPNGateCrawlerResponse.DiscardUnknown(m)
+func (m *DeleteServerRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteServerRuleResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VPNGateCrawlerResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeleteServerRuleResponse proto.InternalMessageInfo
 
-func (m *VPNGateCrawlerResponse) GetApi() string {
+func (m *DeleteServerRuleResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *VPNGateCrawlerResponse) GetData() []*VPNServer {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
 // Verify Apple Receipt request
 type VerifyAppleReceiptRequest struct {
 	// api version
@@ -586,7 +2247,7 @@ func (m *VerifyAppleReceiptRequest) Reset()         { *m = VerifyAppleReceiptReq
 func (m *VerifyAppleReceiptRequest) String() string { return proto.CompactTextString(m) }
 func (*VerifyAppleReceiptRequest) ProtoMessage()    {}
 func (*VerifyAppleReceiptRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{8}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{29}
 }
 func (m *VerifyAppleReceiptRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifyAppleReceiptRequest.Unmarshal(m, b)
@@ -647,7 +2308,7 @@ func (m *VerifyAppleReceiptResponse) Reset()         { *m = VerifyAppleReceiptRe
 func (m *VerifyAppleReceiptResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifyAppleReceiptResponse) ProtoMessage()    {}
 func (*VerifyAppleReceiptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{9}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{30}
 }
 func (m *VerifyAppleReceiptResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifyAppleReceiptResponse.Unmarshal(m, b)
@@ -685,7 +2346,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{10}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{31}
 }
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionRequest.Unmarshal(m, b)
@@ -724,7 +2385,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{11}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{32}
 }
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionResponse.Unmarshal(m, b)
@@ -783,7 +2444,7 @@ func (m *HealthzRequest) Reset()         { *m = HealthzRequest{} }
 func (m *HealthzRequest) String() string { return proto.CompactTextString(m) }
 func (*HealthzRequest) ProtoMessage()    {}
 func (*HealthzRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{12}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{33}
 }
 func (m *HealthzRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HealthzRequest.Unmarshal(m, b)
@@ -816,7 +2477,7 @@ func (m *HealthzResponse) Reset()         { *m = HealthzResponse{} }
 func (m *HealthzResponse) String() string { return proto.CompactTextString(m) }
 func (*HealthzResponse) ProtoMessage()    {}
 func (*HealthzResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vpn_ad34332875b0786a, []int{13}
+	return fileDescriptor_vpn_3ad25e34caf02cdd, []int{34}
 }
 func (m *HealthzResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HealthzResponse.Unmarshal(m, b)
@@ -850,8 +2511,29 @@ func init() {
 	proto.RegisterType((*ListCountriesResponse)(nil), "v1.ListCountriesResponse")
 	proto.RegisterType((*ListVPNServerRequest)(nil), "v1.ListVPNServerRequest")
 	proto.RegisterType((*ListVPNServerResponse)(nil), "v1.ListVPNServerResponse")
+	proto.RegisterType((*ListRecommendedServersRequest)(nil), "v1.ListRecommendedServersRequest")
+	proto.RegisterType((*ListRecommendedServersResponse)(nil), "v1.ListRecommendedServersResponse")
+	proto.RegisterType((*ListNearestServersRequest)(nil), "v1.ListNearestServersRequest")
+	proto.RegisterType((*ListNearestServersResponse)(nil), "v1.ListNearestServersResponse")
 	proto.RegisterType((*VPNGateCrawlerRequest)(nil), "v1.VPNGateCrawlerRequest")
 	proto.RegisterType((*VPNGateCrawlerResponse)(nil), "v1.VPNGateCrawlerResponse")
+	proto.RegisterType((*GetOpenVPNProfileRequest)(nil), "v1.GetOpenVPNProfileRequest")
+	proto.RegisterType((*GetOpenVPNProfileResponse)(nil), "v1.GetOpenVPNProfileResponse")
+	proto.RegisterType((*MetricPoint)(nil), "v1.MetricPoint")
+	proto.RegisterType((*GetServerMetricsRequest)(nil), "v1.GetServerMetricsRequest")
+	proto.RegisterType((*GetServerMetricsResponse)(nil), "v1.GetServerMetricsResponse")
+	proto.RegisterType((*CrawlRun)(nil), "v1.CrawlRun")
+	proto.RegisterType((*ListCrawlRunsRequest)(nil), "v1.ListCrawlRunsRequest")
+	proto.RegisterType((*ListCrawlRunsResponse)(nil), "v1.ListCrawlRunsResponse")
+	proto.RegisterType((*GetCrawlRunRequest)(nil), "v1.GetCrawlRunRequest")
+	proto.RegisterType((*GetCrawlRunResponse)(nil), "v1.GetCrawlRunResponse")
+	proto.RegisterType((*ServerRule)(nil), "v1.ServerRule")
+	proto.RegisterType((*CreateServerRuleRequest)(nil), "v1.CreateServerRuleRequest")
+	proto.RegisterType((*CreateServerRuleResponse)(nil), "v1.CreateServerRuleResponse")
+	proto.RegisterType((*ListServerRulesRequest)(nil), "v1.ListServerRulesRequest")
+	proto.RegisterType((*ListServerRulesResponse)(nil), "v1.ListServerRulesResponse")
+	proto.RegisterType((*DeleteServerRuleRequest)(nil), "v1.DeleteServerRuleRequest")
+	proto.RegisterType((*DeleteServerRuleResponse)(nil), "v1.DeleteServerRuleResponse")
 	proto.RegisterType((*VerifyAppleReceiptRequest)(nil), "v1.VerifyAppleReceiptRequest")
 	proto.RegisterType((*VerifyAppleReceiptResponse)(nil), "v1.VerifyAppleReceiptResponse")
 	proto.RegisterType((*VersionRequest)(nil), "v1.VersionRequest")
@@ -873,7 +2555,7 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ServiceClient interface {
-	// crawl all vpn server
+	// crawl all vpn servers from the enabled sources, admin only, only the leader replica crawls and the others answer UNAVAILABLE, ABORTED while a crawl is in progress
 	VPNGateCrawler(ctx context.Context, in *VPNGateCrawlerRequest, opts ...grpc.CallOption) (*VPNGateCrawlerResponse, error)
 	// API Version
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
@@ -885,6 +2567,25 @@ type ServiceClient interface {
 	ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesResponse, error)
 	// List all VPN servers
 	ListVPNServers(ctx context.Context, in *ListVPNServerRequest, opts ...grpc.CallOption) (*ListVPNServerResponse, error)
+	// List the best ranked VPN servers
+	ListRecommendedServers(ctx context.Context, in *ListRecommendedServersRequest, opts ...grpc.CallOption) (*ListRecommendedServersResponse, error)
+	// List the VPN servers nearest to the caller location
+	ListNearestServers(ctx context.Context, in *ListNearestServersRequest, opts ...grpc.CallOption) (*ListNearestServersResponse, error)
+	// Download a ready-to-use OpenVPN profile of a VPN server with its own protocol and port,
+	// the HTTP gateway serves it as a file instead of JSON
+	GetOpenVPNProfile(ctx context.Context, in *GetOpenVPNProfileRequest, opts ...grpc.CallOption) (*GetOpenVPNProfileResponse, error)
+	// Get the downsampled metric history of a VPN server
+	GetServerMetrics(ctx context.Context, in *GetServerMetricsRequest, opts ...grpc.CallOption) (*GetServerMetricsResponse, error)
+	// List the latest crawl runs, admin only
+	ListCrawlRuns(ctx context.Context, in *ListCrawlRunsRequest, opts ...grpc.CallOption) (*ListCrawlRunsResponse, error)
+	// Get a crawl run, admin only
+	GetCrawlRun(ctx context.Context, in *GetCrawlRunRequest, opts ...grpc.CallOption) (*GetCrawlRunResponse, error)
+	// Create a server rule, admin only
+	CreateServerRule(ctx context.Context, in *CreateServerRuleRequest, opts ...grpc.CallOption) (*CreateServerRuleResponse, error)
+	// List server rules, admin only
+	ListServerRules(ctx context.Context, in *ListServerRulesRequest, opts ...grpc.CallOption) (*ListServerRulesResponse, error)
+	// Delete a server rule, admin only
+	DeleteServerRule(ctx context.Context, in *DeleteServerRuleRequest, opts ...grpc.CallOption) (*DeleteServerRuleResponse, error)
 }
 
 type serviceClient struct {
@@ -949,9 +2650,90 @@ func (c *serviceClient) ListVPNServers(ctx context.Context, in *ListVPNServerReq
 	return out, nil
 }
 
+func (c *serviceClient) ListRecommendedServers(ctx context.Context, in *ListRecommendedServersRequest, opts ...grpc.CallOption) (*ListRecommendedServersResponse, error) {
+	out := new(ListRecommendedServersResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/ListRecommendedServers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListNearestServers(ctx context.Context, in *ListNearestServersRequest, opts ...grpc.CallOption) (*ListNearestServersResponse, error) {
+	out := new(ListNearestServersResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/ListNearestServers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetOpenVPNProfile(ctx context.Context, in *GetOpenVPNProfileRequest, opts ...grpc.CallOption) (*GetOpenVPNProfileResponse, error) {
+	out := new(GetOpenVPNProfileResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/GetOpenVPNProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetServerMetrics(ctx context.Context, in *GetServerMetricsRequest, opts ...grpc.CallOption) (*GetServerMetricsResponse, error) {
+	out := new(GetServerMetricsResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/GetServerMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListCrawlRuns(ctx context.Context, in *ListCrawlRunsRequest, opts ...grpc.CallOption) (*ListCrawlRunsResponse, error) {
+	out := new(ListCrawlRunsResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/ListCrawlRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetCrawlRun(ctx context.Context, in *GetCrawlRunRequest, opts ...grpc.CallOption) (*GetCrawlRunResponse, error) {
+	out := new(GetCrawlRunResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/GetCrawlRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateServerRule(ctx context.Context, in *CreateServerRuleRequest, opts ...grpc.CallOption) (*CreateServerRuleResponse, error) {
+	out := new(CreateServerRuleResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/CreateServerRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListServerRules(ctx context.Context, in *ListServerRulesRequest, opts ...grpc.CallOption) (*ListServerRulesResponse, error) {
+	out := new(ListServerRulesResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/ListServerRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteServerRule(ctx context.Context, in *DeleteServerRuleRequest, opts ...grpc.CallOption) (*DeleteServerRuleResponse, error) {
+	out := new(DeleteServerRuleResponse)
+	err := c.cc.Invoke(ctx, "/v1.Service/DeleteServerRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
-	// crawl all vpn server
+	// crawl all vpn servers from the enabled sources, admin only, only the leader replica crawls and the others answer UNAVAILABLE, ABORTED while a crawl is in progress
 	VPNGateCrawler(context.Context, *VPNGateCrawlerRequest) (*VPNGateCrawlerResponse, error)
 	// API Version
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
@@ -963,6 +2745,25 @@ type ServiceServer interface {
 	ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error)
 	// List all VPN servers
 	ListVPNServers(context.Context, *ListVPNServerRequest) (*ListVPNServerResponse, error)
+	// List the best ranked VPN servers
+	ListRecommendedServers(context.Context, *ListRecommendedServersRequest) (*ListRecommendedServersResponse, error)
+	// List the VPN servers nearest to the caller location
+	ListNearestServers(context.Context, *ListNearestServersRequest) (*ListNearestServersResponse, error)
+	// Download a ready-to-use OpenVPN profile of a VPN server with its own protocol and port,
+	// the HTTP gateway serves it as a file instead of JSON
+	GetOpenVPNProfile(context.Context, *GetOpenVPNProfileRequest) (*GetOpenVPNProfileResponse, error)
+	// Get the downsampled metric history of a VPN server
+	GetServerMetrics(context.Context, *GetServerMetricsRequest) (*GetServerMetricsResponse, error)
+	// List the latest crawl runs, admin only
+	ListCrawlRuns(context.Context, *ListCrawlRunsRequest) (*ListCrawlRunsResponse, error)
+	// Get a crawl run, admin only
+	GetCrawlRun(context.Context, *GetCrawlRunRequest) (*GetCrawlRunResponse, error)
+	// Create a server rule, admin only
+	CreateServerRule(context.Context, *CreateServerRuleRequest) (*CreateServerRuleResponse, error)
+	// List server rules, admin only
+	ListServerRules(context.Context, *ListServerRulesRequest) (*ListServerRulesResponse, error)
+	// Delete a server rule, admin only
+	DeleteServerRule(context.Context, *DeleteServerRuleRequest) (*DeleteServerRuleResponse, error)
 }
 
 func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
@@ -1077,6 +2878,168 @@ func _Service_ListVPNServers_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_ListRecommendedServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecommendedServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListRecommendedServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/ListRecommendedServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListRecommendedServers(ctx, req.(*ListRecommendedServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListNearestServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNearestServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListNearestServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/ListNearestServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListNearestServers(ctx, req.(*ListNearestServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetOpenVPNProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpenVPNProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetOpenVPNProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/GetOpenVPNProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetOpenVPNProfile(ctx, req.(*GetOpenVPNProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetServerMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetServerMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/GetServerMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetServerMetrics(ctx, req.(*GetServerMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListCrawlRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCrawlRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListCrawlRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/ListCrawlRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListCrawlRuns(ctx, req.(*ListCrawlRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetCrawlRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCrawlRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetCrawlRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/GetCrawlRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetCrawlRun(ctx, req.(*GetCrawlRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateServerRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServerRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateServerRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/CreateServerRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateServerRule(ctx, req.(*CreateServerRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListServerRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServerRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListServerRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/ListServerRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListServerRules(ctx, req.(*ListServerRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteServerRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServerRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteServerRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Service/DeleteServerRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteServerRule(ctx, req.(*DeleteServerRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1105,69 +3068,189 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListVPNServers",
 			Handler:    _Service_ListVPNServers_Handler,
 		},
+		{
+			MethodName: "ListRecommendedServers",
+			Handler:    _Service_ListRecommendedServers_Handler,
+		},
+		{
+			MethodName: "ListNearestServers",
+			Handler:    _Service_ListNearestServers_Handler,
+		},
+		{
+			MethodName: "GetOpenVPNProfile",
+			Handler:    _Service_GetOpenVPNProfile_Handler,
+		},
+		{
+			MethodName: "GetServerMetrics",
+			Handler:    _Service_GetServerMetrics_Handler,
+		},
+		{
+			MethodName: "ListCrawlRuns",
+			Handler:    _Service_ListCrawlRuns_Handler,
+		},
+		{
+			MethodName: "GetCrawlRun",
+			Handler:    _Service_GetCrawlRun_Handler,
+		},
+		{
+			MethodName: "CreateServerRule",
+			Handler:    _Service_CreateServerRule_Handler,
+		},
+		{
+			MethodName: "ListServerRules",
+			Handler:    _Service_ListServerRules_Handler,
+		},
+		{
+			MethodName: "DeleteServerRule",
+			Handler:    _Service_DeleteServerRule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vpn.proto",
 }
 
-func init() { proto.RegisterFile("vpn.proto", fileDescriptor_vpn_ad34332875b0786a) }
-
-var fileDescriptor_vpn_ad34332875b0786a = []byte{
-	// 896 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdb, 0x6e, 0x23, 0x45,
-	0x13, 0xfe, 0x7d, 0x48, 0x1c, 0x97, 0xd7, 0x8e, 0xff, 0xde, 0x4d, 0xe8, 0x8c, 0xf6, 0x60, 0x86,
-	0x83, 0xbc, 0x2b, 0x62, 0x2b, 0x41, 0x42, 0x08, 0xae, 0x82, 0x8d, 0x16, 0x56, 0x8b, 0x13, 0xcd,
-	0x7a, 0x2d, 0x04, 0x57, 0x9d, 0x99, 0xf6, 0xa4, 0xa5, 0xf1, 0x74, 0xd3, 0xdd, 0xf6, 0x12, 0x2e,
-	0xb9, 0xe5, 0x92, 0x5b, 0xde, 0x8a, 0x57, 0xe0, 0x01, 0x78, 0x04, 0xd4, 0x07, 0x9f, 0xe3, 0x70,
-	0xc1, 0xd5, 0x4c, 0x7d, 0x55, 0xf5, 0xd5, 0xd7, 0xd5, 0xd5, 0x05, 0xd5, 0x99, 0xc8, 0x3b, 0x42,
-	0x72, 0xcd, 0x51, 0x71, 0x76, 0x16, 0x3c, 0x4b, 0x39, 0x4f, 0x33, 0xda, 0xb5, 0xc8, 0xf5, 0x74,
-	0xdc, 0xd5, 0x6c, 0x42, 0x95, 0x26, 0x13, 0xe1, 0x82, 0x82, 0xc7, 0x3e, 0x80, 0x08, 0xd6, 0x25,
-	0x79, 0xce, 0x35, 0xd1, 0x8c, 0xe7, 0xca, 0x7b, 0x3f, 0xb1, 0x9f, 0xf8, 0x34, 0xa5, 0xf9, 0xa9,
-	0x7a, 0x47, 0xd2, 0x94, 0xca, 0x2e, 0x17, 0x36, 0x62, 0x3b, 0x3a, 0xbc, 0x80, 0x4a, 0x8f, 0x4f,
-	0x73, 0x2d, 0x6f, 0x51, 0x03, 0x8a, 0x2c, 0xc1, 0x85, 0x56, 0xa1, 0xbd, 0x17, 0x15, 0x59, 0x82,
-	0x10, 0x94, 0x73, 0x32, 0xa1, 0xb8, 0xd8, 0x2a, 0xb4, 0xab, 0x91, 0xfd, 0x37, 0x58, 0xcc, 0x13,
-	0x8a, 0x4b, 0x0e, 0x33, 0xff, 0xe1, 0x1f, 0x65, 0xa8, 0x8e, 0xae, 0x06, 0x6f, 0xa8, 0x9c, 0x51,
-	0xb9, 0xc5, 0x12, 0xc0, 0xc1, 0x0d, 0x57, 0x7a, 0xb0, 0x64, 0x5a, 0xd8, 0x36, 0x56, 0x78, 0xae,
-	0x22, 0x13, 0xe8, 0x11, 0xec, 0xa9, 0x98, 0x4b, 0x8a, 0xcb, 0x36, 0xdd, 0x19, 0xa6, 0xa6, 0x60,
-	0x79, 0x8a, 0xf7, 0x2c, 0x68, 0xff, 0x6d, 0xa4, 0xa0, 0x34, 0xc1, 0xfb, 0xad, 0x42, 0xbb, 0x14,
-	0x39, 0x03, 0x7d, 0x04, 0x95, 0xd8, 0x1d, 0x06, 0x57, 0x5a, 0x85, 0x76, 0xed, 0xbc, 0xd6, 0x99,
-	0x9d, 0x75, 0xfc, 0xf9, 0xa2, 0xb9, 0x0f, 0x7d, 0x0c, 0x8d, 0x7c, 0x3a, 0xb1, 0x92, 0x95, 0x32,
-	0xbd, 0xc0, 0x07, 0x96, 0x7a, 0x03, 0x45, 0xc7, 0xb0, 0x3f, 0x15, 0xa6, 0xf9, 0xb8, 0x6a, 0xab,
-	0x78, 0x0b, 0x3d, 0x05, 0xd0, 0x5c, 0x93, 0xec, 0xad, 0xa2, 0x52, 0x61, 0xb0, 0xb9, 0x2b, 0x08,
-	0x0a, 0xe1, 0x81, 0xb5, 0x86, 0x92, 0x8c, 0xc7, 0x2c, 0xc6, 0x35, 0x9b, 0xbd, 0x86, 0x21, 0x0c,
-	0x95, 0x8c, 0xa7, 0xc3, 0x5b, 0x41, 0xf1, 0x03, 0x7b, 0xfe, 0xb9, 0x69, 0x1a, 0xc6, 0x05, 0x95,
-	0x44, 0x73, 0x89, 0xeb, 0xae, 0x61, 0x73, 0xdb, 0x64, 0x4d, 0xa8, 0x52, 0x24, 0xa5, 0xb8, 0xe1,
-	0xb2, 0xbc, 0x89, 0x3e, 0x84, 0x3a, 0x17, 0x34, 0x1f, 0x5d, 0x0d, 0x7a, 0x3c, 0x1f, 0xb3, 0x14,
-	0x1f, 0x5a, 0xff, 0x3a, 0x88, 0x3e, 0x87, 0x6a, 0x2c, 0x29, 0xd1, 0x34, 0xb9, 0xd0, 0xb8, 0x69,
-	0x5b, 0x14, 0x74, 0xdc, 0x34, 0x75, 0xe6, 0xe3, 0xd6, 0x19, 0xce, 0xc7, 0x2d, 0x5a, 0x06, 0x9b,
-	0xcc, 0xa9, 0x48, 0x7c, 0xe6, 0xff, 0xff, 0x3d, 0x73, 0x11, 0x1c, 0xb6, 0xe1, 0xd1, 0x6b, 0xa6,
-	0xb4, 0xbb, 0x05, 0x46, 0x55, 0x44, 0x7f, 0x9a, 0x52, 0xa5, 0x51, 0x13, 0x4a, 0x44, 0x30, 0x3b,
-	0x29, 0xd5, 0xc8, 0xfc, 0x86, 0xaf, 0xe0, 0x68, 0x23, 0x52, 0x09, 0x9e, 0x2b, 0xba, 0x1d, 0x8a,
-	0x9e, 0x41, 0x39, 0x21, 0x9a, 0xe0, 0x62, 0xab, 0xb4, 0x79, 0xcd, 0xd6, 0x11, 0xbe, 0x72, 0x55,
-	0x17, 0x73, 0xb9, 0xb3, 0x2a, 0x6a, 0x41, 0xcd, 0x0f, 0x46, 0xcf, 0x4c, 0xb6, 0x9b, 0xd1, 0x55,
-	0x28, 0x7c, 0xed, 0x74, 0xad, 0x70, 0xed, 0xd4, 0xf5, 0xfe, 0x9a, 0xae, 0xba, 0xd1, 0xb5, 0x4c,
-	0x73, 0xca, 0x9e, 0xc3, 0xd1, 0xe8, 0x6a, 0xf0, 0x92, 0x68, 0xda, 0x93, 0xe4, 0x5d, 0x76, 0x8f,
-	0xb4, 0xf0, 0x3b, 0x38, 0xde, 0x0c, 0xfd, 0x2f, 0x95, 0xff, 0x2e, 0xc0, 0xc9, 0x88, 0x4a, 0x36,
-	0xbe, 0xbd, 0x10, 0x22, 0xa3, 0x11, 0x8d, 0x29, 0x13, 0xfa, 0xde, 0xce, 0x48, 0x17, 0xd3, 0x77,
-	0xcc, 0xb6, 0x33, 0x2b, 0x10, 0xfa, 0x0c, 0x8e, 0xe9, 0xcf, 0x71, 0x36, 0x4d, 0xe8, 0x65, 0x96,
-	0x0c, 0x25, 0xc9, 0x15, 0x89, 0xed, 0x76, 0xb1, 0x8f, 0xfa, 0x20, 0xda, 0xe1, 0x45, 0x5f, 0x42,
-	0x89, 0xe6, 0x33, 0xfb, 0xcc, 0x1b, 0xe7, 0xcf, 0xad, 0xd6, 0x5d, 0xba, 0x3a, 0x5f, 0xe7, 0x33,
-	0x26, 0x79, 0x3e, 0xa1, 0xb9, 0x8e, 0x4c, 0x56, 0xf8, 0x02, 0x6a, 0x2b, 0x18, 0xaa, 0x41, 0xe5,
-	0xcd, 0xc5, 0xa0, 0xff, 0xd5, 0xe5, 0xf7, 0xcd, 0xff, 0xa1, 0x06, 0xc0, 0x55, 0x74, 0xd9, 0x7f,
-	0xdb, 0x1b, 0x7e, 0x7b, 0x39, 0x68, 0x16, 0xc2, 0x0e, 0x04, 0x77, 0x31, 0xef, 0xea, 0x62, 0xd8,
-	0x84, 0xc6, 0x88, 0x4a, 0xf3, 0xfc, 0x7d, 0xf9, 0x50, 0xc1, 0xe1, 0x02, 0xd9, 0xd9, 0xfc, 0xc7,
-	0x50, 0xbd, 0x9e, 0xb2, 0x2c, 0x31, 0x0f, 0xc0, 0xf7, 0x69, 0x09, 0x98, 0x3d, 0x12, 0xf3, 0xc9,
-	0x84, 0x69, 0xbf, 0xea, 0xbc, 0x65, 0x5e, 0xb3, 0xa4, 0x19, 0x25, 0xca, 0x2d, 0xbc, 0x6a, 0x34,
-	0x37, 0x8d, 0x8c, 0x6f, 0x28, 0xc9, 0xf4, 0xcd, 0x2f, 0x73, 0x19, 0x1f, 0xc0, 0xe1, 0x02, 0xd9,
-	0x25, 0xe3, 0xfc, 0xb7, 0x32, 0x54, 0xcc, 0x8d, 0xb3, 0x98, 0xa2, 0x97, 0xd0, 0x58, 0x9f, 0x1d,
-	0x74, 0xe2, 0x67, 0x62, 0x7b, 0xf4, 0x82, 0xe0, 0x2e, 0x97, 0x2f, 0xd3, 0x87, 0x8a, 0x6f, 0x00,
-	0x42, 0xfe, 0xa6, 0x56, 0xfa, 0x13, 0x3c, 0x5c, 0xc3, 0x5c, 0x4e, 0xd8, 0xfc, 0xf5, 0xcf, 0xbf,
-	0x7e, 0x2f, 0x02, 0x3a, 0xe8, 0xce, 0x7c, 0x6a, 0x1f, 0x2a, 0x5e, 0xbf, 0x63, 0x59, 0x3f, 0x9e,
-	0x63, 0xd9, 0x38, 0xe0, 0x0a, 0xcb, 0x8d, 0x4f, 0x95, 0x80, 0xb6, 0xaf, 0x13, 0x3d, 0xb9, 0x77,
-	0x80, 0x82, 0xa7, 0xbb, 0xdc, 0xbe, 0xcc, 0x13, 0x5b, 0xe6, 0xbd, 0x10, 0x75, 0x67, 0x67, 0x46,
-	0x2f, 0x1b, 0xdf, 0x9e, 0xfa, 0x21, 0xff, 0xa2, 0xf0, 0x02, 0xfd, 0x08, 0xf5, 0xb5, 0xad, 0x84,
-	0xb0, 0xe1, 0xbb, 0x6b, 0xa5, 0x05, 0x27, 0x77, 0x78, 0x7c, 0x91, 0x23, 0x5b, 0xe4, 0x10, 0xd5,
-	0x4d, 0x91, 0x78, 0xc1, 0xf5, 0x03, 0x34, 0xd6, 0x56, 0xcb, 0x0a, 0xfb, 0xe6, 0xea, 0x5a, 0xb2,
-	0x6f, 0x2d, 0xa2, 0xf0, 0xa1, 0x65, 0xaf, 0xa3, 0x9a, 0x61, 0x57, 0x8e, 0xe9, 0x7a, 0xdf, 0xee,
-	0xe5, 0x4f, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xaa, 0xe1, 0x29, 0x6f, 0x5f, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("vpn.proto", fileDescriptor_vpn_3ad25e34caf02cdd) }
+
+var fileDescriptor_vpn_3ad25e34caf02cdd = []byte{
+	// 2229 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x5d, 0x6e, 0x1b, 0xc9,
+	0xf1, 0xff, 0x93, 0x14, 0x2d, 0xb1, 0x64, 0x7d, 0x6c, 0xcb, 0x92, 0x5a, 0xa3, 0x0f, 0xd3, 0xe3,
+	0xdd, 0x3f, 0xb4, 0xc6, 0x9a, 0x82, 0x1d, 0xc0, 0x58, 0xec, 0x3e, 0x69, 0xa5, 0xac, 0x77, 0xd7,
+	0x6b, 0x89, 0x18, 0xcb, 0x42, 0x90, 0x3c, 0x8d, 0x66, 0x5a, 0xd4, 0xec, 0x92, 0x33, 0x93, 0xee,
+	0x26, 0x6d, 0x6d, 0x3e, 0x10, 0x24, 0x48, 0x90, 0xe7, 0xe4, 0x0c, 0x01, 0x72, 0x81, 0xdc, 0x23,
+	0x40, 0x0e, 0x90, 0x97, 0x1c, 0x20, 0x47, 0x08, 0xaa, 0xba, 0x67, 0x38, 0x1c, 0x72, 0x24, 0x07,
+	0x7e, 0xcc, 0x13, 0xa7, 0x7e, 0x55, 0x5d, 0x55, 0x5d, 0x5d, 0x5d, 0x55, 0x4d, 0x68, 0x8d, 0xd2,
+	0xb8, 0x93, 0xca, 0x44, 0x27, 0xac, 0x3e, 0x7a, 0xe2, 0xdc, 0xef, 0x25, 0x49, 0xaf, 0x2f, 0x0e,
+	0x08, 0xb9, 0x18, 0x5e, 0x1e, 0xe8, 0x68, 0x20, 0x94, 0xf6, 0x07, 0xa9, 0x11, 0x72, 0x76, 0xac,
+	0x80, 0x9f, 0x46, 0x07, 0x7e, 0x1c, 0x27, 0xda, 0xd7, 0x51, 0x12, 0x2b, 0xcb, 0xfd, 0x84, 0x7e,
+	0x82, 0xc7, 0x3d, 0x11, 0x3f, 0x56, 0x6f, 0xfc, 0x5e, 0x4f, 0xc8, 0x83, 0x24, 0x25, 0x89, 0x69,
+	0x69, 0xf7, 0x10, 0xe6, 0x8f, 0x92, 0x61, 0xac, 0xe5, 0x35, 0x5b, 0x86, 0x7a, 0x14, 0xf2, 0x5a,
+	0xbb, 0xb6, 0xdf, 0xf4, 0xea, 0x51, 0xc8, 0x18, 0xcc, 0xc5, 0xfe, 0x40, 0xf0, 0x7a, 0xbb, 0xb6,
+	0xdf, 0xf2, 0xe8, 0x1b, 0xb1, 0x20, 0x09, 0x05, 0x6f, 0x18, 0x0c, 0xbf, 0xdd, 0x3f, 0xb6, 0xa0,
+	0x75, 0xde, 0x3d, 0x79, 0x25, 0xe4, 0x48, 0xc8, 0x29, 0x2d, 0x0e, 0x2c, 0x5c, 0x25, 0x4a, 0x9f,
+	0x8c, 0x35, 0xe5, 0x34, 0xc9, 0xa6, 0x56, 0x57, 0x3d, 0x4a, 0xd9, 0x3d, 0x68, 0xaa, 0x20, 0x91,
+	0x82, 0xcf, 0xd1, 0x72, 0x43, 0xa0, 0xcd, 0x34, 0x8a, 0x7b, 0xbc, 0x49, 0x20, 0x7d, 0x93, 0x64,
+	0x2a, 0x44, 0xc8, 0xef, 0xb4, 0x6b, 0xfb, 0x0d, 0xcf, 0x10, 0xec, 0x23, 0x98, 0x0f, 0xcc, 0x66,
+	0xf8, 0x7c, 0xbb, 0xb6, 0xbf, 0xf8, 0x74, 0xb1, 0x33, 0x7a, 0xd2, 0xb1, 0xfb, 0xf3, 0x32, 0x1e,
+	0xfb, 0x7f, 0x58, 0x8e, 0x87, 0x03, 0x72, 0x59, 0x29, 0x8c, 0x05, 0x5f, 0x20, 0xd5, 0x25, 0x94,
+	0x6d, 0xc0, 0x9d, 0x61, 0x8a, 0xc1, 0xe7, 0x2d, 0xb2, 0x62, 0x29, 0xb6, 0x07, 0xa0, 0x13, 0xed,
+	0xf7, 0x5f, 0x2b, 0x21, 0x15, 0x07, 0x5a, 0x5b, 0x40, 0x98, 0x0b, 0x77, 0x89, 0x3a, 0x93, 0xfe,
+	0xe5, 0x65, 0x14, 0xf0, 0x45, 0x5a, 0x3d, 0x81, 0x31, 0x0e, 0xf3, 0xfd, 0xa4, 0x77, 0x76, 0x9d,
+	0x0a, 0x7e, 0x97, 0xf6, 0x9f, 0x91, 0x18, 0xb0, 0x24, 0x15, 0xd2, 0xd7, 0x89, 0xe4, 0x4b, 0x26,
+	0x60, 0x19, 0x8d, 0xab, 0x06, 0x42, 0x29, 0xbf, 0x27, 0xf8, 0xb2, 0x59, 0x65, 0x49, 0xf6, 0x21,
+	0x2c, 0x25, 0xa9, 0x88, 0xcf, 0xbb, 0x27, 0x47, 0x49, 0x7c, 0x19, 0xf5, 0xf8, 0x0a, 0xf1, 0x27,
+	0x41, 0xf6, 0x29, 0xb4, 0x02, 0x29, 0x7c, 0x2d, 0xc2, 0x43, 0xcd, 0x57, 0x29, 0x44, 0x4e, 0xc7,
+	0x64, 0x53, 0x27, 0x4b, 0xb7, 0xce, 0x59, 0x96, 0x6e, 0xde, 0x58, 0x18, 0x57, 0x0e, 0xd3, 0xd0,
+	0xae, 0xfc, 0xe0, 0xf6, 0x95, 0xb9, 0x30, 0x46, 0x51, 0x25, 0x43, 0x19, 0x08, 0xce, 0xc8, 0x25,
+	0x4b, 0xb1, 0xcf, 0x00, 0xfa, 0xbe, 0xd2, 0xaf, 0x84, 0x88, 0x0f, 0x35, 0x5f, 0xbb, 0x55, 0x65,
+	0x41, 0x1a, 0x63, 0x64, 0xb2, 0x3c, 0xe9, 0xf3, 0x7b, 0x26, 0x46, 0x19, 0x4d, 0xe9, 0x92, 0x48,
+	0xcd, 0xd7, 0x6d, 0xba, 0x24, 0x52, 0xb3, 0x36, 0x2c, 0x4a, 0x31, 0x48, 0xb4, 0xf8, 0x2a, 0x51,
+	0x5a, 0xf1, 0x8d, 0x76, 0x63, 0xbf, 0xe5, 0x15, 0x21, 0xf4, 0x32, 0x88, 0xd2, 0x2b, 0x21, 0xf9,
+	0xa6, 0xf1, 0xd2, 0x50, 0x78, 0xd6, 0xfe, 0x50, 0x5f, 0x1d, 0x47, 0x3d, 0xa1, 0x34, 0xe7, 0xc4,
+	0x2b, 0x20, 0xa8, 0x39, 0x48, 0x06, 0xa9, 0x34, 0x39, 0xc3, 0xb7, 0x48, 0xa0, 0x08, 0xa1, 0xaf,
+	0x62, 0x70, 0x21, 0x42, 0x75, 0x74, 0xc8, 0x9d, 0x76, 0x6d, 0x7f, 0xc1, 0xcb, 0x69, 0xd4, 0x6e,
+	0xbf, 0x85, 0xd4, 0x7c, 0x9b, 0xb8, 0x05, 0x84, 0xed, 0x40, 0xcb, 0x50, 0x2f, 0xc4, 0x35, 0xdf,
+	0x21, 0xf6, 0x18, 0x40, 0xae, 0x14, 0x7e, 0x70, 0xe5, 0x5f, 0xf4, 0x05, 0xdf, 0x35, 0xdc, 0x1c,
+	0xc0, 0x2c, 0x4f, 0x65, 0x72, 0x21, 0xbe, 0xf5, 0xb5, 0x88, 0x83, 0xeb, 0x97, 0x8a, 0xef, 0x99,
+	0x2c, 0x9f, 0x44, 0x31, 0x73, 0x08, 0xf9, 0xd2, 0x8f, 0xfa, 0x43, 0x29, 0x14, 0xbf, 0x4f, 0x62,
+	0x93, 0x20, 0x7b, 0x46, 0x11, 0xbf, 0xa0, 0xe3, 0x6f, 0xdf, 0x7a, 0x56, 0xb9, 0x2c, 0x9e, 0x86,
+	0xf4, 0xe3, 0xef, 0xf9, 0x83, 0x76, 0x6d, 0xbf, 0xe6, 0xd1, 0x37, 0x46, 0xa4, 0xef, 0xeb, 0x48,
+	0x0f, 0x43, 0xc1, 0x5d, 0xc2, 0x73, 0x1a, 0xf7, 0xd4, 0x4f, 0xe2, 0x9e, 0x61, 0x3e, 0x24, 0xe6,
+	0x18, 0xc0, 0x78, 0x85, 0x91, 0xd2, 0x7e, 0x1c, 0x88, 0x17, 0x03, 0xfe, 0x21, 0xb1, 0x0b, 0x88,
+	0xbb, 0x0f, 0xf7, 0xbe, 0x8d, 0x94, 0x36, 0x37, 0x3e, 0x12, 0xca, 0x13, 0x3f, 0x1f, 0xe2, 0x29,
+	0xad, 0x42, 0xc3, 0x4f, 0x23, 0xaa, 0x4a, 0x2d, 0x0f, 0x3f, 0xdd, 0x6f, 0x60, 0xbd, 0x24, 0xa9,
+	0xd2, 0x24, 0x56, 0x62, 0x5a, 0x94, 0xdd, 0x87, 0xb9, 0xd0, 0xd7, 0x3e, 0xaf, 0xb7, 0x1b, 0xe5,
+	0x92, 0x42, 0x0c, 0xf7, 0x0f, 0x0d, 0x63, 0x36, 0x2f, 0x82, 0x95, 0x66, 0x4d, 0xba, 0xd0, 0xda,
+	0xa3, 0x24, 0xcc, 0x0a, 0x62, 0x11, 0x62, 0x1d, 0x60, 0x51, 0x1c, 0xf4, 0x87, 0xa1, 0x78, 0x1d,
+	0x8f, 0x4f, 0xb7, 0x41, 0xa7, 0x3b, 0x83, 0x83, 0x01, 0x56, 0x98, 0xee, 0x73, 0xa6, 0x22, 0xe3,
+	0x37, 0x5d, 0x0f, 0xbf, 0x27, 0x5e, 0x45, 0x3f, 0x08, 0xaa, 0x9a, 0x4b, 0x5e, 0x4e, 0x63, 0x80,
+	0xf1, 0xfb, 0x2c, 0xf9, 0x5e, 0xc4, 0x54, 0x3d, 0x5b, 0xde, 0x18, 0xc0, 0x95, 0x83, 0x28, 0x7e,
+	0x45, 0xa5, 0x75, 0x9e, 0xca, 0x56, 0x4e, 0x53, 0xf1, 0xf1, 0xdf, 0x76, 0xb1, 0x14, 0x9b, 0x7a,
+	0x99, 0x91, 0xb8, 0xab, 0x81, 0xff, 0x36, 0xaf, 0xa6, 0x2d, 0xe2, 0x16, 0xa1, 0x89, 0x0b, 0x0b,
+	0xa5, 0x0b, 0x5b, 0x2c, 0x78, 0x8b, 0xa5, 0x82, 0x47, 0xc5, 0x43, 0xea, 0x2f, 0xae, 0x6d, 0x95,
+	0xb4, 0x14, 0xd6, 0xff, 0x44, 0x86, 0x22, 0xab, 0x90, 0x86, 0x70, 0xff, 0x54, 0x83, 0xf5, 0xd2,
+	0x41, 0x54, 0x9e, 0xea, 0x83, 0x89, 0x53, 0x5d, 0xc2, 0x53, 0x1d, 0x2f, 0x23, 0x16, 0xde, 0x8c,
+	0x58, 0xbc, 0xd5, 0xdd, 0x3c, 0x5c, 0xa6, 0x53, 0x4d, 0x82, 0x79, 0x37, 0xa0, 0x9c, 0xa0, 0x63,
+	0x68, 0x78, 0x05, 0xc4, 0x8d, 0x60, 0x17, 0x7d, 0xf2, 0x44, 0x90, 0x0c, 0x06, 0x22, 0x0e, 0x45,
+	0x68, 0x8c, 0xa8, 0xf7, 0xc9, 0x92, 0x7b, 0xd0, 0xec, 0x47, 0x83, 0x48, 0x93, 0x4b, 0x4b, 0x9e,
+	0x21, 0xdc, 0xd7, 0xb0, 0x57, 0x65, 0xea, 0x3d, 0xe2, 0xe0, 0x1e, 0xc1, 0x16, 0xaa, 0x3d, 0x11,
+	0xbe, 0x14, 0x4a, 0x1b, 0xd6, 0x0d, 0xde, 0xe7, 0xbe, 0xd5, 0x8b, 0xbe, 0x29, 0x70, 0x66, 0x29,
+	0xa9, 0xf4, 0xeb, 0xf6, 0x18, 0x64, 0x9e, 0x37, 0xaa, 0x3d, 0xff, 0x18, 0xd6, 0xcf, 0xbb, 0x27,
+	0xcf, 0x7d, 0x2d, 0x8e, 0xa4, 0xff, 0xa6, 0x7f, 0xc3, 0xcd, 0x74, 0x5f, 0xc2, 0x46, 0x59, 0xf4,
+	0x7d, 0x62, 0xf6, 0x6b, 0xe0, 0xcf, 0x85, 0x3e, 0x35, 0xdd, 0xb7, 0x2b, 0x93, 0xcb, 0xa8, 0x2f,
+	0xaa, 0x43, 0x66, 0x86, 0xa6, 0x7a, 0x3e, 0x34, 0x51, 0x9d, 0x93, 0x22, 0xd0, 0xd1, 0x48, 0x28,
+	0x3e, 0x47, 0xed, 0xaa, 0x80, 0xa0, 0x86, 0x30, 0x56, 0xbc, 0x49, 0x0c, 0xfc, 0xfc, 0x66, 0x6e,
+	0xa1, 0xb1, 0x3a, 0x37, 0xbe, 0x54, 0xee, 0xef, 0x6b, 0xb0, 0x35, 0xc3, 0x81, 0xca, 0x2d, 0x39,
+	0xb0, 0x80, 0x12, 0xc5, 0x31, 0x2d, 0xa3, 0xcd, 0x51, 0xc4, 0x5a, 0xc4, 0x9a, 0xe6, 0x95, 0x46,
+	0x76, 0x14, 0x39, 0x84, 0xa5, 0xc1, 0x92, 0x74, 0x01, 0xee, 0x7a, 0x19, 0xe9, 0xfe, 0xb3, 0x06,
+	0x8b, 0x2f, 0x85, 0x96, 0x51, 0xd0, 0x4d, 0xa2, 0x58, 0xb3, 0x0e, 0xcc, 0xd1, 0x44, 0x55, 0xbb,
+	0xb5, 0x87, 0x90, 0xdc, 0x78, 0x24, 0xac, 0x53, 0xb1, 0x2f, 0x8d, 0x84, 0x0d, 0xd3, 0x55, 0x26,
+	0x47, 0xc2, 0x39, 0x2b, 0x89, 0xc4, 0x8c, 0x59, 0xaf, 0x49, 0xec, 0x12, 0x5a, 0x9a, 0xe9, 0xee,
+	0x90, 0x4c, 0x01, 0xc1, 0x1d, 0x2a, 0x7f, 0x90, 0xf6, 0x85, 0xa2, 0xba, 0xd8, 0xf4, 0x32, 0xd2,
+	0xfd, 0x5b, 0x0d, 0x36, 0x9f, 0x0b, 0x9b, 0xd1, 0x66, 0xab, 0xea, 0xdd, 0x4f, 0xba, 0x03, 0x73,
+	0x97, 0x32, 0x19, 0xf0, 0xc6, 0xed, 0xf1, 0x40, 0x39, 0xf6, 0x08, 0xea, 0x3a, 0xe1, 0x73, 0xb7,
+	0x4a, 0xd7, 0x75, 0x82, 0xa5, 0x1e, 0x2b, 0x34, 0xc6, 0x5d, 0xd9, 0x3e, 0x30, 0x06, 0xdc, 0x5f,
+	0x02, 0x9f, 0x76, 0xbb, 0x32, 0x3f, 0x1e, 0x4e, 0xa4, 0xfc, 0x0a, 0xa6, 0x7c, 0xe1, 0x58, 0x6d,
+	0xc1, 0xfc, 0x18, 0xe6, 0xfd, 0x91, 0x90, 0x38, 0x9e, 0x9a, 0xfd, 0x4c, 0xc9, 0x65, 0x7c, 0xf7,
+	0xaf, 0x0d, 0x58, 0xa0, 0x8b, 0xe6, 0x0d, 0xe3, 0xa9, 0x37, 0xc3, 0x78, 0x64, 0xac, 0x4f, 0x8c,
+	0x8c, 0x9f, 0x42, 0x4b, 0x69, 0x5f, 0x9a, 0x21, 0xf4, 0xf6, 0x88, 0x8d, 0x85, 0x71, 0xd8, 0xbc,
+	0x8c, 0xe2, 0x48, 0x5d, 0xd1, 0xd2, 0xdb, 0xc3, 0x57, 0x90, 0xa6, 0xe1, 0x31, 0x79, 0xa3, 0xbe,
+	0x14, 0x3a, 0xb8, 0x12, 0xa1, 0x7d, 0x86, 0x14, 0x21, 0x4c, 0x1e, 0x24, 0xbb, 0xbe, 0x54, 0xf6,
+	0x49, 0xd2, 0xf4, 0x0a, 0x08, 0x3e, 0x08, 0x90, 0xf2, 0xc4, 0x77, 0x22, 0xd0, 0xb6, 0xb3, 0x36,
+	0xbd, 0x09, 0x0c, 0x2f, 0x60, 0x14, 0x2b, 0x81, 0xfe, 0xda, 0xf6, 0x9a, 0xd3, 0x98, 0x7c, 0x76,
+	0x9e, 0xb6, 0xbd, 0x35, 0x23, 0x91, 0x83, 0x53, 0xec, 0x48, 0x84, 0xf6, 0x1d, 0x92, 0x91, 0x78,
+	0x1d, 0x84, 0x94, 0x79, 0x4b, 0x35, 0x04, 0x5a, 0x09, 0x2f, 0x70, 0x9b, 0x2f, 0x15, 0x75, 0xd4,
+	0xa6, 0x97, 0xd3, 0x14, 0x75, 0xed, 0xeb, 0xa1, 0xb2, 0x4d, 0xd5, 0x52, 0xee, 0xb9, 0x1d, 0xaa,
+	0xec, 0x69, 0xdd, 0x90, 0xdc, 0x55, 0xe7, 0x36, 0xbb, 0x5b, 0xbd, 0x80, 0xf5, 0x92, 0xde, 0x1b,
+	0x9a, 0x41, 0x31, 0xfb, 0xee, 0xd2, 0x08, 0x66, 0x97, 0xd9, 0x7a, 0xfb, 0x0c, 0xd8, 0x73, 0x91,
+	0xeb, 0x7a, 0xe7, 0xfb, 0xe7, 0x7e, 0x0d, 0x6b, 0x13, 0xeb, 0xde, 0xc1, 0x85, 0x5a, 0x85, 0x0b,
+	0x7f, 0xaf, 0x03, 0xd8, 0x1e, 0x30, 0xec, 0x8b, 0x59, 0x49, 0xed, 0x07, 0xf8, 0xf4, 0xce, 0x82,
+	0x63, 0x28, 0xc4, 0xa3, 0xf4, 0x28, 0x0a, 0xa5, 0x2d, 0xac, 0x96, 0x62, 0xfb, 0xb0, 0x92, 0x3d,
+	0x94, 0xbb, 0xbe, 0xd6, 0x42, 0xc6, 0x76, 0xc6, 0x2b, 0xc3, 0x28, 0x99, 0x0d, 0x4c, 0x99, 0x64,
+	0xd3, 0x48, 0x96, 0xe0, 0x72, 0x53, 0xbd, 0x33, 0xdd, 0x54, 0xa9, 0x92, 0xe3, 0xf0, 0xa0, 0x29,
+	0x4b, 0x5b, 0x5e, 0x46, 0xe2, 0xe5, 0x13, 0x6f, 0xd3, 0x48, 0x0a, 0x75, 0xa8, 0xf9, 0xc2, 0xad,
+	0x37, 0x68, 0x2c, 0x3c, 0xf9, 0xea, 0x6c, 0xfd, 0x17, 0xaf, 0x4e, 0xf7, 0x2f, 0x75, 0xd8, 0x3c,
+	0x22, 0x6a, 0x1c, 0xd8, 0x1b, 0xd3, 0xef, 0x7f, 0x33, 0xc2, 0x6e, 0x17, 0xf8, 0x74, 0x98, 0x2a,
+	0x53, 0xd9, 0x9d, 0x48, 0xe5, 0x65, 0x4c, 0xe5, 0xc2, 0x3a, 0x93, 0xcc, 0x8f, 0x60, 0x03, 0x2f,
+	0xe7, 0x18, 0xbf, 0xe1, 0x2d, 0x75, 0x0a, 0x9b, 0x53, 0xb2, 0xef, 0x60, 0xbc, 0x51, 0x69, 0xfc,
+	0x73, 0xd8, 0x3c, 0x16, 0x7d, 0xf1, 0x6e, 0xa7, 0x5e, 0xbe, 0xd1, 0x9f, 0x00, 0x9f, 0x5e, 0x5c,
+	0xe5, 0x8e, 0xfb, 0xef, 0x1a, 0x6c, 0x9d, 0x0b, 0x19, 0x5d, 0x5e, 0x1f, 0xa6, 0x29, 0x4a, 0x06,
+	0x22, 0x4a, 0xf5, 0x8d, 0xa3, 0xb9, 0x34, 0x32, 0xc7, 0x59, 0x08, 0x5b, 0x5e, 0x11, 0x62, 0xcf,
+	0x60, 0x43, 0xbc, 0xa5, 0x67, 0xda, 0x69, 0x3f, 0x3c, 0x93, 0x7e, 0xac, 0x4c, 0x1a, 0x2a, 0xfb,
+	0x88, 0xab, 0xe0, 0xb2, 0xcf, 0xa1, 0x21, 0xe2, 0x11, 0x65, 0xe0, 0xf2, 0xd3, 0x8f, 0x69, 0xa6,
+	0xac, 0xf2, 0xab, 0xf3, 0xe3, 0x78, 0x14, 0xc9, 0x24, 0xc6, 0xac, 0xf1, 0x70, 0x95, 0xfb, 0x08,
+	0x16, 0x0b, 0x18, 0x5b, 0x84, 0xf9, 0x57, 0x87, 0x27, 0xc7, 0x5f, 0x9c, 0xfe, 0x64, 0xf5, 0xff,
+	0xd8, 0x32, 0x40, 0xd7, 0x3b, 0x3d, 0x7e, 0x7d, 0x74, 0xf6, 0xf5, 0xe9, 0xc9, 0x6a, 0xcd, 0xed,
+	0x80, 0x33, 0x4b, 0x73, 0x65, 0x88, 0x56, 0x61, 0xf9, 0x5c, 0x48, 0x1c, 0x93, 0xac, 0x79, 0x57,
+	0xc1, 0x4a, 0x8e, 0x54, 0x1e, 0xf4, 0x0e, 0xb4, 0x2e, 0x86, 0x51, 0x3f, 0xc4, 0x84, 0xb5, 0x71,
+	0x1a, 0x03, 0xf4, 0x7f, 0x4b, 0x32, 0xc8, 0x7a, 0x42, 0xcb, 0xb3, 0x94, 0x69, 0x68, 0x7d, 0xe1,
+	0x2b, 0x61, 0xef, 0x62, 0x46, 0xa2, 0x1b, 0x5f, 0x09, 0xbf, 0xaf, 0xaf, 0x7e, 0xc8, 0xdc, 0x78,
+	0x08, 0x2b, 0x39, 0x52, 0xe5, 0xc6, 0xd3, 0xdf, 0xdd, 0x85, 0x79, 0xcc, 0x84, 0x28, 0x10, 0xec,
+	0x39, 0x2c, 0x4f, 0xce, 0xf8, 0x6c, 0xcb, 0xce, 0xee, 0xd3, 0x4f, 0x04, 0xc7, 0x99, 0xc5, 0xb2,
+	0x66, 0x8e, 0x61, 0xde, 0x06, 0x80, 0x31, 0x7b, 0x52, 0x85, 0xf8, 0x38, 0x6b, 0x13, 0x98, 0x59,
+	0xe3, 0xae, 0xfe, 0xf6, 0x1f, 0xff, 0xfa, 0x73, 0x1d, 0xd8, 0xc2, 0xc1, 0xc8, 0x2e, 0x3d, 0x86,
+	0x79, 0xeb, 0xbf, 0xd1, 0x32, 0xb9, 0x3d, 0x67, 0x6d, 0x02, 0x9b, 0xd2, 0x72, 0x65, 0x97, 0x4a,
+	0x60, 0xd3, 0xc7, 0xc9, 0x76, 0x6f, 0x4c, 0x20, 0x67, 0xaf, 0x8a, 0x6d, 0xcd, 0xec, 0x92, 0x99,
+	0x4d, 0x97, 0x1d, 0x8c, 0x9e, 0xa0, 0xbf, 0xd1, 0xe5, 0xf5, 0x63, 0x9b, 0xe4, 0x9f, 0xd5, 0x1e,
+	0xb1, 0x9f, 0xc1, 0xd2, 0xc4, 0xbf, 0x27, 0x8c, 0xa3, 0xbe, 0x59, 0x7f, 0xbd, 0x38, 0x5b, 0x33,
+	0x38, 0xd6, 0xc8, 0x3a, 0x19, 0x59, 0x61, 0x4b, 0x68, 0x24, 0xc8, 0x75, 0xfd, 0x14, 0x96, 0x27,
+	0x1e, 0xf1, 0x05, 0xed, 0xe5, 0x7f, 0x58, 0x9c, 0xad, 0x19, 0x1c, 0xab, 0x7d, 0x8d, 0xb4, 0x2f,
+	0xb1, 0x45, 0xd4, 0xae, 0xac, 0xa6, 0xdf, 0xd4, 0x60, 0x63, 0xf6, 0x13, 0x99, 0x3d, 0xc8, 0x54,
+	0x55, 0xbe, 0xd4, 0x1d, 0xf7, 0x26, 0x11, 0x6b, 0xf6, 0x3e, 0x99, 0xdd, 0x62, 0x9b, 0x05, 0xb3,
+	0x07, 0x72, 0x2c, 0xcf, 0x52, 0x60, 0xd3, 0x0f, 0x61, 0x73, 0x5e, 0x95, 0xaf, 0x6c, 0x67, 0xaf,
+	0x8a, 0x6d, 0xad, 0x6e, 0x93, 0xd5, 0x75, 0xb6, 0x56, 0xb4, 0x1a, 0x1b, 0x59, 0xf6, 0x06, 0x3e,
+	0x98, 0x7a, 0x0a, 0xb2, 0x1d, 0xd4, 0x58, 0xf5, 0x44, 0x75, 0x76, 0x2b, 0xb8, 0xd6, 0xdc, 0x47,
+	0x64, 0xee, 0x3e, 0xdb, 0x2d, 0x9a, 0xfb, 0x45, 0x14, 0xfe, 0xea, 0x20, 0x35, 0x92, 0x9d, 0x64,
+	0x94, 0xc6, 0x2c, 0x81, 0xd5, 0xf2, 0x13, 0x83, 0x6d, 0x5b, 0xcd, 0xb3, 0xde, 0x4b, 0xce, 0xce,
+	0x6c, 0xa6, 0xb5, 0xda, 0x26, 0xab, 0x0e, 0xe3, 0x53, 0x56, 0x07, 0x56, 0xf9, 0x85, 0xcd, 0xcb,
+	0x6c, 0xa4, 0x2c, 0xe4, 0x65, 0x69, 0x7a, 0x75, 0xb6, 0x66, 0x70, 0xac, 0x9d, 0x1d, 0xb2, 0xb3,
+	0xc1, 0xee, 0xa1, 0x1d, 0x3f, 0x1c, 0x44, 0xf1, 0x41, 0x80, 0x42, 0x8f, 0x25, 0xaa, 0xf4, 0x61,
+	0xb1, 0x30, 0x31, 0xb2, 0x0d, 0xeb, 0x72, 0x69, 0xf4, 0x74, 0x36, 0xa7, 0x70, 0xab, 0xfd, 0x01,
+	0x69, 0xdf, 0x66, 0x5b, 0xb3, 0xb4, 0xd3, 0x76, 0x58, 0x0a, 0xab, 0xe5, 0x76, 0x6e, 0xe2, 0x56,
+	0x31, 0x0b, 0x39, 0x3b, 0xb3, 0x99, 0x93, 0x16, 0xdd, 0x8d, 0xb1, 0x45, 0x13, 0xbd, 0xc7, 0x12,
+	0x9b, 0x35, 0x5e, 0xe8, 0xef, 0x60, 0xa5, 0xd4, 0xc2, 0x99, 0x93, 0x05, 0x68, 0x7a, 0x06, 0x70,
+	0xb6, 0x67, 0xf2, 0xac, 0xb9, 0x3d, 0x32, 0xc7, 0x59, 0x85, 0x39, 0x26, 0x61, 0xb5, 0xdc, 0xa0,
+	0xcd, 0xee, 0x2a, 0x7a, 0xbe, 0xb3, 0x33, 0x9b, 0x69, 0xcd, 0x3d, 0x24, 0x73, 0xbb, 0x8f, 0xb6,
+	0x67, 0x9b, 0xa3, 0x88, 0x5e, 0xdc, 0xa1, 0xf9, 0xe9, 0x47, 0xff, 0x19, 0x00, 0x4d, 0xf5, 0x0b,
+	0xdc, 0x6b, 0x1b, 0x00, 0x00,
 }
@@ -19,6 +19,9 @@ import (
 var (
 	kEnvApiKey = "API_KEY"
 	kApiKey    = "x-api-key"
+
+	kEnvAdminKey = "ADMIN_API_KEY"
+	kAdminKey    = "x-admin-key"
 )
 
 var (
@@ -181,3 +184,19 @@ func VerifyClientKey(ctx context.Context) (context.Context, error) {
 	}
 	return nil, status.Errorf(codes.Unauthenticated, "could not get client key")
 }
+
+// VerifyAdminKey verifies the admin key of operator only methods.
+// Every request is rejected when no admin key is configured.
+func VerifyAdminKey(ctx context.Context) (context.Context, error) {
+	adminKey := os.Getenv(kEnvAdminKey)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(adminKey) == 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "could not get admin key")
+	}
+	if len(md.Get(kAdminKey)) > 0 {
+		if md.Get(kAdminKey)[0] == adminKey {
+			return ctx, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "could not get admin key")
+}
@@ -0,0 +1,110 @@
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// MetadataKey is the gRPC metadata key the HTTP gateway forwards the client IP address with
+const MetadataKey = "x-client-ip"
+
+type ctxKeyClientIP int
+
+// clientIPKey is the key that holds the client IP address in a context
+const clientIPKey ctxKeyClientIP = 0
+
+// NewContext returns a copy of ctx holding the client IP address
+func NewContext(ctx context.Context, ip net.IP) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// FromContext returns the client IP address held by ctx or nil
+func FromContext(ctx context.Context) net.IP {
+	ip, _ := ctx.Value(clientIPKey).(net.IP)
+	return ip
+}
+
+// Proxies are the networks of the reverse proxies trusted to report the client address
+type Proxies []*net.IPNet
+
+// ParseProxies parses a comma separated list of IP addresses and CIDR networks e.g. 10.0.0.0/8,192.168.1.1
+func ParseProxies(s string) (Proxies, error) {
+	var proxies Proxies
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: '%s'", v)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: '%s'", v)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Contains reports whether ip is the address of a trusted proxy
+func (p Proxies) Contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromRequest returns the IP address of the client of r. The forwarding headers can be set by anyone so
+// they are only read when the remote address is a trusted proxy: X-Forwarded-For is walked from the right,
+// the hop nearest to us, and the first address that is not a trusted proxy is the client. X-Real-IP is
+// used when X-Forwarded-For holds no address, and the remote address when the peer is not trusted.
+func FromRequest(r *http.Request, trusted Proxies) net.IP {
+	remote := FromAddr(r.RemoteAddr)
+	if remote == nil || !trusted.Contains(remote) {
+		return remote
+	}
+	var first net.IP
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// a hop we cannot read breaks the chain of trusted proxies
+			break
+		}
+		if !trusted.Contains(ip) {
+			return ip
+		}
+		first = ip
+	}
+	if first != nil {
+		// every hop is a trusted proxy, the client is on the internal network
+		return first
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip
+	}
+	return remote
+}
+
+// FromAddr parses the IP address of a host:port or host address
+func FromAddr(addr string) net.IP {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return net.ParseIP(addr)
+}
@@ -0,0 +1,80 @@
+package clientip
+
+import (
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestParseProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		ip      string
+		want    bool
+		wantErr bool
+	}{
+		{"Empty list should trust nobody", "", "10.0.0.1", false, false},
+		{"Network should trust its addresses", "10.0.0.0/8, 192.168.0.0/16", "192.168.1.1", true, false},
+		{"Network should not trust other addresses", "10.0.0.0/8", "11.0.0.1", false, false},
+		{"Address should trust itself", "203.0.113.7", "203.0.113.7", true, false},
+		{"IPv6 address should trust itself", "2001:db8::1", "2001:db8::1", true, false},
+		{"Address should not trust its neighbour", "203.0.113.7", "203.0.113.8", false, false},
+		{"Invalid address should fail", "10.0.0.256", "", false, true},
+		{"Invalid network should fail", "10.0.0.0/33", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxies, err := ParseProxies(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProxies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := proxies.Contains(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("ParseProxies().Contains(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	trusted, err := ParseProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{"Direct client should be the remote address", "203.0.113.7:5000", nil, "", "203.0.113.7"},
+		{"Headers of an untrusted peer should be ignored", "203.0.113.7:5000", []string{"198.51.100.1"}, "198.51.100.2", "203.0.113.7"},
+		{"Trusted proxy should forward the client", "10.0.0.2:5000", []string{"198.51.100.1"}, "", "198.51.100.1"},
+		{"Spoofed hop should be left of the client", "10.0.0.2:5000", []string{"1.1.1.1, 198.51.100.1"}, "", "198.51.100.1"},
+		{"Trusted hops should be skipped", "10.0.0.2:5000", []string{"1.1.1.1, 198.51.100.1, 10.0.0.3"}, "", "198.51.100.1"},
+		{"Repeated headers should be one list", "10.0.0.2:5000", []string{"1.1.1.1", "198.51.100.1"}, "", "198.51.100.1"},
+		{"Internal client should be the leftmost trusted hop", "10.0.0.2:5000", []string{"10.0.0.4, 10.0.0.3"}, "", "10.0.0.4"},
+		{"Unreadable hop should end the chain", "10.0.0.2:5000", []string{"198.51.100.1, unknown, 10.0.0.3"}, "", "10.0.0.3"},
+		{"Real IP of a trusted proxy should be used without forwarded hops", "10.0.0.2:5000", nil, "198.51.100.2", "198.51.100.2"},
+		{"Trusted proxy without headers should be the client", "10.0.0.2:5000", nil, "", "10.0.0.2"},
+		{"Remote address without port should be parsed", "203.0.113.7", nil, "", "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}}
+			for _, fwd := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", fwd)
+			}
+			if len(tt.realIP) > 0 {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if got := FromRequest(r, trusted); !got.Equal(net.ParseIP(tt.want)) {
+				t.Errorf("FromRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
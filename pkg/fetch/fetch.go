@@ -0,0 +1,236 @@
+package fetch
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotModified denotes the feed did not change since the last remembered response
+	ErrNotModified = errors.New("feed not modified")
+	// ErrTooLarge denotes the response body is bigger than the configured maximum size
+	ErrTooLarge = errors.New("response body too large")
+	// ErrNoURL denotes Fetch was called without any URL
+	ErrNoURL = errors.New("no URL to fetch")
+)
+
+// Config is configuration of a Client
+type Config struct {
+	// Timeout is time limit of a single attempt, including reading the body
+	Timeout time.Duration
+	// MaxSize is the largest accepted body in bytes after decompression, 0 is no limit
+	MaxSize int64
+	// Retries is number of attempts per URL after the first failed one
+	Retries int
+	// Backoff is the wait before the first retry, it doubles on every retry
+	Backoff time.Duration
+	// Proxy is URL of an HTTP proxy, the environment proxy settings are used when it is empty
+	Proxy string
+	// UserAgent is sent with every request
+	UserAgent string
+}
+
+// Validators are the cache validators of a response
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// Response is a fetched feed
+type Response struct {
+	// URL is the URL the feed was fetched from
+	URL  string
+	Body []byte
+	// Header is the header of the HTTP response
+	Header     http.Header
+	Validators Validators
+}
+
+// Client downloads feeds from a list of mirrors with retries and conditional requests
+type Client struct {
+	cfg    Config
+	client *http.Client
+
+	mu         sync.Mutex
+	validators map[string]Validators
+}
+
+// statusError is an unexpected HTTP status, 5xx and 429 are worth a retry
+type statusError struct {
+	code   int
+	status string
+}
+
+func (e *statusError) Error() string {
+	return "unexpected status " + e.status
+}
+
+func (e *statusError) temporary() bool {
+	return e.code >= 500 || e.code == http.StatusTooManyRequests
+}
+
+// Fetch downloads the feed from the first URL that answers, URLs are tried in order.
+// The request is conditional when a response of the same URL was remembered,
+// ErrNotModified is returned when the server reports the feed did not change.
+func (c *Client) Fetch(ctx context.Context, urls []string) (*Response, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoURL
+	}
+	var errs []string
+	for _, u := range urls {
+		res, err := c.fetchWithRetries(ctx, u)
+		if err == nil || err == ErrNotModified {
+			return res, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		errs = append(errs, u+": "+err.Error())
+	}
+	return nil, errors.New("fetch failed: " + strings.Join(errs, "; "))
+}
+
+// Remember keeps the validators of res, the next fetch of its URL is conditional.
+// Callers remember a response once they persisted it so a failed write is retried with the next fetch.
+func (c *Client) Remember(res *Response) {
+	if res == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(res.Validators.ETag) == 0 && len(res.Validators.LastModified) == 0 {
+		delete(c.validators, res.URL)
+		return
+	}
+	c.validators[res.URL] = res.Validators
+}
+
+func (c *Client) fetchWithRetries(ctx context.Context, u string) (*Response, error) {
+	backoff := c.cfg.Backoff
+	for attempt := 0; ; attempt++ {
+		res, err := c.fetch(ctx, u)
+		if err == nil || err == ErrNotModified {
+			return res, err
+		}
+		if attempt >= c.cfg.Retries || !retryable(err) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) fetch(ctx context.Context, u string) (*Response, error) {
+	if c.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept-Encoding", "gzip")
+	if len(c.cfg.UserAgent) > 0 {
+		req.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+	c.mu.Lock()
+	v, ok := c.validators[u]
+	c.mu.Unlock()
+	if ok {
+		if len(v.ETag) > 0 {
+			req.Header.Set("If-None-Match", v.ETag)
+		}
+		if len(v.LastModified) > 0 {
+			req.Header.Set("If-Modified-Since", v.LastModified)
+		}
+	}
+
+	response, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &statusError{code: response.StatusCode, status: response.Status}
+	}
+
+	var body io.Reader = response.Body
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	if c.cfg.MaxSize > 0 {
+		body = io.LimitReader(body, c.cfg.MaxSize+1)
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.MaxSize > 0 && int64(len(content)) > c.cfg.MaxSize {
+		return nil, ErrTooLarge
+	}
+	return &Response{
+		URL:    u,
+		Body:   content,
+		Header: response.Header,
+		Validators: Validators{
+			ETag:         response.Header.Get("ETag"),
+			LastModified: response.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
+
+// retryable reports whether a failed attempt is worth retrying,
+// client errors and oversized bodies would fail the same way again
+func retryable(err error) bool {
+	if err == ErrTooLarge {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.temporary()
+	}
+	return true
+}
+
+// NewClient creates a fetch client
+func NewClient(cfg Config) (*Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(cfg.Proxy) > 0 {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL '%s': %v", cfg.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	return &Client{
+		cfg:        cfg,
+		client:     &http.Client{Transport: transport},
+		validators: make(map[string]Validators),
+	}, nil
+}
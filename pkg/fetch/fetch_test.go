@@ -0,0 +1,181 @@
+package fetch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Fetch(t *testing.T) {
+	feed := []byte("*vpn_servers\n*\n")
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, _ = gz.Write(feed)
+	_ = gz.Close()
+
+	type args struct {
+		cfg Config
+		// failures is number of requests answered with 503 before the feed is served
+		failures int32
+		// status answers every request when it is set
+		status int
+		gzip   bool
+		// mirror makes the first URL unreachable so the test server is the second mirror
+		mirror bool
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     []byte
+		wantHits int32
+		wantErr  bool
+	}{
+		{
+			"Feed",
+			args{
+				cfg: Config{UserAgent: "test-agent"},
+			},
+			feed,
+			1,
+			false,
+		},
+		{
+			"Gzip encoded feed",
+			args{
+				gzip: true,
+			},
+			feed,
+			1,
+			false,
+		},
+		{
+			"Retries until the server recovers",
+			args{
+				cfg:      Config{Retries: 2, Backoff: time.Millisecond},
+				failures: 2,
+			},
+			feed,
+			3,
+			false,
+		},
+		{
+			"Retries exhausted should be error",
+			args{
+				cfg:      Config{Retries: 1, Backoff: time.Millisecond},
+				failures: 2,
+			},
+			nil,
+			2,
+			true,
+		},
+		{
+			"Client errors are not retried",
+			args{
+				cfg:    Config{Retries: 3, Backoff: time.Millisecond},
+				status: http.StatusNotFound,
+			},
+			nil,
+			1,
+			true,
+		},
+		{
+			"Feed larger than max size should be error",
+			args{
+				cfg: Config{MaxSize: 4, Retries: 3, Backoff: time.Millisecond},
+			},
+			nil,
+			1,
+			true,
+		},
+		{
+			"Next mirror is tried",
+			args{
+				mirror: true,
+			},
+			feed,
+			1,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&hits, 1)
+				if len(tt.args.cfg.UserAgent) > 0 && r.UserAgent() != tt.args.cfg.UserAgent {
+					t.Errorf("User-Agent = %s, want %s", r.UserAgent(), tt.args.cfg.UserAgent)
+				}
+				if tt.args.status != 0 {
+					w.WriteHeader(tt.args.status)
+					return
+				}
+				if n <= tt.args.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if tt.args.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+					_, _ = w.Write(gzipped.Bytes())
+					return
+				}
+				_, _ = w.Write(feed)
+			}))
+			defer server.Close()
+
+			urls := []string{server.URL}
+			if tt.args.mirror {
+				urls = []string{"http://127.0.0.1:0/", server.URL}
+			}
+			client, err := NewClient(tt.args.cfg)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			got, err := client.Fetch(context.Background(), urls)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if hits != tt.wantHits {
+				t.Errorf("Fetch() hits = %d, want %d", hits, tt.wantHits)
+			}
+			if !tt.wantErr && !bytes.Equal(got.Body, tt.want) {
+				t.Errorf("Fetch() = %q, want %q", got.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Remember(t *testing.T) {
+	const etag = `"v1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("feed"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	res, err := client.Fetch(context.Background(), []string{server.URL})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	// the feed is fetched again until it is remembered
+	if _, err := client.Fetch(context.Background(), []string{server.URL}); err != nil {
+		t.Fatalf("Fetch() before Remember error = %v", err)
+	}
+	client.Remember(res)
+	if _, err := client.Fetch(context.Background(), []string{server.URL}); err != ErrNotModified {
+		t.Errorf("Fetch() after Remember error = %v, want %v", err, ErrNotModified)
+	}
+}
@@ -0,0 +1,85 @@
+package geoip
+
+import (
+	"errors"
+	"math"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+var (
+	// ErrNotFound denotes the database has no location for an IP address
+	ErrNotFound = errors.New("ip address location not found")
+)
+
+// earthRadiusKm is the mean radius of the earth
+const earthRadiusKm = 6371.0
+
+// Location is where an IP address is located
+type Location struct {
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+}
+
+// record is the subset of a GeoLite2/GeoIP2 City record we read
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+}
+
+// DB looks up IP addresses in a MaxMind format .mmdb file, it is safe for concurrent use
+type DB struct {
+	reader *maxminddb.Reader
+}
+
+// Open opens a MaxMind format .mmdb file e.g. GeoLite2-City.mmdb
+func Open(path string) (*DB, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{reader: reader}, nil
+}
+
+// Lookup finds the location of ip, ErrNotFound is returned when the database has no coordinates for it
+func (db *DB) Lookup(ip net.IP) (*Location, error) {
+	if ip == nil {
+		return nil, ErrNotFound
+	}
+	var r record
+	if err := db.reader.Lookup(ip, &r); err != nil {
+		return nil, err
+	}
+	if r.Location.Latitude == nil || r.Location.Longitude == nil {
+		return nil, ErrNotFound
+	}
+	return &Location{
+		CountryCode: r.Country.ISOCode,
+		Latitude:    *r.Location.Latitude,
+		Longitude:   *r.Location.Longitude,
+	}, nil
+}
+
+// Close closes the database file
+func (db *DB) Close() error {
+	return db.reader.Close()
+}
+
+// Distance returns the great circle distance in kilometers between two coordinates in degrees
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
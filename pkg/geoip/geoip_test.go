@@ -0,0 +1,59 @@
+package geoip
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	type args struct {
+		lat1 float64
+		lon1 float64
+		lat2 float64
+		lon2 float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			"Same point",
+			args{
+				35.6895,
+				139.6917,
+				35.6895,
+				139.6917,
+			},
+			0,
+		},
+		{
+			"Tokyo to Seoul",
+			args{
+				35.6895,
+				139.6917,
+				37.5665,
+				126.9780,
+			},
+			1160,
+		},
+		{
+			"Antipodes",
+			args{
+				0,
+				0,
+				0,
+				180,
+			},
+			math.Pi * earthRadiusKm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// coordinates are rounded, 1% is close enough
+			if got := Distance(tt.args.lat1, tt.args.lon1, tt.args.lat2, tt.args.lon2); math.Abs(got-tt.want) > tt.want/100 {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
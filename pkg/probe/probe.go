@@ -0,0 +1,74 @@
+package probe
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net"
+	"time"
+)
+
+var (
+	// ErrUnexpectedReply denotes a UDP server answered with something that is not an OpenVPN reset
+	ErrUnexpectedReply = errors.New("unexpected OpenVPN reply")
+)
+
+const (
+	// opcodes of OpenVPN control packets, they are stored in the high 5 bits of the first byte
+	opHardResetClientV2 = 7
+	opHardResetServerV2 = 8
+)
+
+// TCP opens a TCP connection to addr and returns how long the connection took
+func TCP(ctx context.Context, addr string, timeout time.Duration) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+	_ = conn.Close()
+	return latency, nil
+}
+
+// OpenVPNUDP sends an OpenVPN client hard reset to addr and returns how long the server took to
+// answer with a server hard reset. Servers using tls-auth drop the packet and time out.
+func OpenVPNUDP(ctx context.Context, addr string, timeout time.Duration) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// opcode and key id, session id, empty ack array, packet id
+	packet := make([]byte, 14)
+	packet[0] = opHardResetClientV2 << 3
+	if _, err := rand.Read(packet[1:9]); err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if _, err := conn.Write(packet); err != nil {
+		return 0, err
+	}
+	reply := make([]byte, 1500)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 || reply[0]>>3 != opHardResetServerV2 {
+		return 0, ErrUnexpectedReply
+	}
+	return time.Since(start), nil
+}
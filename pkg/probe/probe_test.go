@@ -0,0 +1,125 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// closedTCPAddr returns a local address nothing listens on
+func closedTCPAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+	return addr
+}
+
+// serveOpenVPNUDP answers every packet it receives with the result of reply, a nil result is not sent.
+// Every received packet is sent to packets.
+func serveOpenVPNUDP(t *testing.T, reply func(packet []byte) []byte, packets chan<- []byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			packet := append([]byte(nil), buf[:n]...)
+			if packets != nil {
+				packets <- packet
+			}
+			if res := reply(packet); res != nil {
+				_, _ = conn.WriteTo(res, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr bool
+	}{
+		{"Listening port should be reachable", l.Addr().String(), false},
+		{"Closed port should be unreachable", closedTCPAddr(t), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latency, err := TCP(context.Background(), tt.addr, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TCP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && latency <= 0 {
+				t.Errorf("TCP() latency = %v, want a positive latency", latency)
+			}
+		})
+	}
+}
+
+func TestOpenVPNUDP(t *testing.T) {
+	serverReset := func([]byte) []byte {
+		return []byte{opHardResetServerV2 << 3, 1, 2, 3, 4, 5, 6, 7, 8, 0, 0, 0, 0, 0}
+	}
+	tests := []struct {
+		name    string
+		reply   func([]byte) []byte
+		wantErr error
+	}{
+		{"Server hard reset should be reachable", serverReset, nil},
+		{"Other reply should be unexpected", func([]byte) []byte { return []byte("HTTP/1.1 400") }, ErrUnexpectedReply},
+		// a timeout of the read is reported as a net.Error
+		{"No reply should time out", func([]byte) []byte { return nil }, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packets := make(chan []byte, 1)
+			addr := serveOpenVPNUDP(t, tt.reply, packets)
+			_, err := OpenVPNUDP(context.Background(), addr, 200*time.Millisecond)
+			if tt.wantErr == context.DeadlineExceeded {
+				var netErr net.Error
+				if !errors.As(err, &netErr) || !netErr.Timeout() {
+					t.Fatalf("OpenVPNUDP() error = %v, want a timeout", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenVPNUDP() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// the probe is a client hard reset: opcode 7 key id 0, a session id, no ack and packet id 0
+			packet := <-packets
+			if len(packet) != 14 || packet[0] != opHardResetClientV2<<3 {
+				t.Fatalf("OpenVPNUDP() sent % x, want a 14 bytes client hard reset", packet)
+			}
+			for i, b := range packet[9:] {
+				if b != 0 {
+					t.Errorf("OpenVPNUDP() sent byte %d = %d after the session id, want 0", 9+i, b)
+				}
+			}
+		})
+	}
+}